@@ -0,0 +1,59 @@
+package awx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// findExistingObject returns the ID of the object of endpoint, e.g.
+// `inventories`, matching naturalKey when the provider adopts existing
+// objects, and 0 otherwise. Keys are API fields, a 0 reference looks up the
+// objects not linked to any. The create functions update the object found
+// instead of creating a duplicate, to bring a hand built AWX under Terraform.
+func findExistingObject(m interface{}, endpoint string, naturalKey map[string]interface{}) (int, diag.Diagnostics) {
+	client := m.(*awxClient)
+	if !client.adoptExisting {
+		return 0, nil
+	}
+
+	params := make(map[string]string, len(naturalKey))
+	var keys []string
+	for k, v := range naturalKey {
+		if v == 0 {
+			params[k+"__isnull"] = "true"
+		} else {
+			params[k] = fmt.Sprint(v)
+		}
+		keys = append(keys, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(keys)
+
+	result := new(struct {
+		Count   int `json:"count"`
+		Results []struct {
+			ID int `json:"id"`
+		} `json:"results"`
+	})
+	err := client.getJSON(fmt.Sprintf("/api/v2/%s/", endpoint), result, params)
+	if err != nil {
+		return 0, buildDiagnosticsMessage(
+			"Create: Fail to look up an existing object",
+			"Fail to look up %s with %s to adopt, got %s",
+			endpoint, strings.Join(keys, ", "), err.Error(),
+		)
+	}
+	switch {
+	case result.Count == 0:
+		return 0, nil
+	case result.Count > 1 || len(result.Results) != 1:
+		return 0, buildDiagnosticsMessage(
+			"Create: Fail to adopt an existing object",
+			"Found %d %s with %s, unable to tell which one to adopt",
+			result.Count, endpoint, strings.Join(keys, ", "),
+		)
+	}
+	return result.Results[0].ID, nil
+}
@@ -12,3 +12,19 @@ func AtoipOr(s string, defaultValue *int) *int {
 	}
 	return &n
 }
+
+// IntpOrNil takes an ID and returns nil when it is 0, the value of unset optional references
+func IntpOrNil(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+// StringpOrNil takes a string and returns nil when it is empty, the value of unset optional attributes
+func StringpOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
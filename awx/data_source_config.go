@@ -0,0 +1,90 @@
+/*
+*TBD*
+
+Example Usage
+
+```hcl
+data "awx_config" "current" {}
+
+output "awx_version" {
+  value = data.awx_config.current.version
+}
+```
+
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConfigRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the connected controller",
+			},
+			"install_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier of the controller installation",
+			},
+			"license_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "License type of the controller, `open` for AWX",
+			},
+			"license_info": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "License details of the controller, nested values are JSON encoded",
+			},
+		},
+	}
+}
+
+func dataSourceConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*awxClient)
+
+	info, err := fetchControllerInfo(client)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch controller config",
+			"Fail to read the controller version and license, got: %s",
+			err.Error(),
+		)
+	}
+
+	licenseInfo := make(map[string]string, len(info.LicenseInfo))
+	for k, v := range info.LicenseInfo {
+		switch v.(type) {
+		case nil:
+			continue
+		case map[string]interface{}, []interface{}:
+			encoded, _ := json.Marshal(v)
+			licenseInfo[k] = string(encoded)
+		default:
+			licenseInfo[k] = fmt.Sprint(v)
+		}
+	}
+
+	d.SetId(info.InstallUUID)
+	if info.InstallUUID == "" {
+		d.SetId(client.requester.Base)
+	}
+	d.Set("version", info.Version)
+	d.Set("install_uuid", info.InstallUUID)
+	d.Set("license_type", licenseInfo["license_type"])
+	d.Set("license_info", licenseInfo)
+	return diags
+}
@@ -0,0 +1,33 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/denouche/terraform-provider-awx/awx/internal/fakeawx"
+)
+
+func TestDataSourceConfig(t *testing.T) {
+	server := newTestServer(t)
+	config := testProviderConfig(server, `
+data "awx_config" "test" {}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.awx_config.test", "version", fakeawx.DefaultVersion),
+			},
+			{
+				PreConfig: func() {
+					server.SetVersion("24.0.0")
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.awx_config.test", "version", "24.0.0"),
+			},
+		},
+	})
+}
@@ -14,7 +14,6 @@ import (
 	"context"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -24,8 +23,10 @@ func dataSourceCredentialByID() *schema.Resource {
 		ReadContext: dataSourceCredentialByIDRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "named_url"},
 			},
 			"tower_id": {
 				Type:     schema.TypeInt,
@@ -39,6 +40,7 @@ func dataSourceCredentialByID() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"named_url": dataSourceNamedURLSchema("Deploy key++Source Control+scm++Engineering"),
 		},
 	}
 }
@@ -46,7 +48,10 @@ func dataSourceCredentialByID() *schema.Resource {
 func dataSourceCredentialByIDRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
+	if diags := setIDFromNamedURL(d, m, "credentials", "id"); diags.HasError() {
+		return diags
+	}
 	id := d.Get("id").(int)
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
@@ -55,6 +60,7 @@ func dataSourceCredentialByIDRead(ctx context.Context, d *schema.ResourceData, m
 			Summary:  "Unable to fetch credential",
 			Detail:   "The given credential ID is invalid or malformed",
 		})
+		return diags
 	}
 
 	d.Set("username", cred.Inputs["username"])
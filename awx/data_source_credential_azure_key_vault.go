@@ -16,7 +16,6 @@ import (
 	"strconv"
 	"time"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -26,8 +25,10 @@ func dataSourceCredentialAzure() *schema.Resource {
 		ReadContext: dataSourceCredentialAzureRead,
 		Schema: map[string]*schema.Schema{
 			"credential_id": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"credential_id", "named_url"},
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -58,6 +59,7 @@ func dataSourceCredentialAzure() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"named_url": dataSourceNamedURLSchema("Vault++Microsoft Azure Key Vault+external++Engineering"),
 		},
 	}
 }
@@ -65,7 +67,10 @@ func dataSourceCredentialAzure() *schema.Resource {
 func dataSourceCredentialAzureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
+	if diags := setIDFromNamedURL(d, m, "credentials", "credential_id"); diags.HasError() {
+		return diags
+	}
 	id, _ := d.Get("credential_id").(int)
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
@@ -0,0 +1,50 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceCredentialAzureKeyVault(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	id := server.Add("credentials", map[string]interface{}{
+		"name": "test", "credential_type": 19, "organization": orgID,
+		"inputs": map[string]interface{}{"url": "https://vault.example.com", "client": "client", "secret": "secret", "tenant": "tenant"},
+	})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_credential_azure_key_vault" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf("credential_id = %d", id)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awx_credential_azure_key_vault.test", "name", "test"),
+					resource.TestCheckResourceAttr("data.awx_credential_azure_key_vault.test", "organization_id", strconv.Itoa(orgID)),
+					resource.TestCheckResourceAttr("data.awx_credential_azure_key_vault.test", "url", "https://vault.example.com"),
+				),
+			},
+			{
+				Config: config(`named_url = "test++Microsoft Azure Key Vault+external++org"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential_azure_key_vault.test", "credential_id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("credentials", id, map[string]interface{}{"inputs": map[string]interface{}{"url": "https://other.example.com", "client": "client", "tenant": "tenant"}})
+				},
+				Config: config(fmt.Sprintf("credential_id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential_azure_key_vault.test", "url", "https://other.example.com"),
+			},
+		},
+	})
+}
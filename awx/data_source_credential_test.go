@@ -0,0 +1,49 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceCredential(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	id := server.Add("credentials", map[string]interface{}{
+		"name": "test", "credential_type": 1, "organization": orgID,
+		"inputs": map[string]interface{}{"username": "root"},
+	})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_credential" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awx_credential.test", "username", "root"),
+					resource.TestCheckResourceAttr("data.awx_credential.test", "kind", "ssh"),
+				),
+			},
+			{
+				Config: config(`named_url = "test++Machine+ssh++org"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("credentials", id, map[string]interface{}{"inputs": map[string]interface{}{"username": "admin"}})
+				},
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential.test", "username", "admin"),
+			},
+		},
+	})
+}
@@ -12,10 +12,10 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -25,8 +25,10 @@ func dataSourceCredentialTypeByID() *schema.Resource {
 		ReadContext: dataSourceCredentialTypeByIDRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "named_url"},
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -48,6 +50,7 @@ func dataSourceCredentialTypeByID() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"named_url": dataSourceNamedURLSchema("Machine+ssh"),
 		},
 	}
 }
@@ -55,7 +58,10 @@ func dataSourceCredentialTypeByID() *schema.Resource {
 func dataSourceCredentialTypeByIDRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
+	if diags := setIDFromNamedURL(d, m, "credential_types", "id"); diags.HasError() {
+		return diags
+	}
 	id := d.Get("id").(int)
 	credType, err := client.CredentialTypeService.GetCredentialTypeByID(id, map[string]string{})
 	if err != nil {
@@ -64,13 +70,16 @@ func dataSourceCredentialTypeByIDRead(ctx context.Context, d *schema.ResourceDat
 			Summary:  "Unable to fetch credential type",
 			Detail:   fmt.Sprintf("Unable to fetch credential type with ID: %d. Error: %s", id, err.Error()),
 		})
+		return diags
 	}
 
 	d.Set("name", credType.Name)
 	d.Set("description", credType.Description)
 	d.Set("kind", credType.Kind)
-	d.Set("inputs", credType.Inputs)
-	d.Set("injectors", credType.Injectors)
+	inputs, _ := json.Marshal(credType.Inputs)
+	d.Set("inputs", string(inputs))
+	injectors, _ := json.Marshal(credType.Injectors)
+	d.Set("injectors", string(injectors))
 	d.SetId(strconv.Itoa(id))
 
 	return diags
@@ -0,0 +1,53 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceCredentialType(t *testing.T) {
+	server := newTestServer(t)
+	id := server.Add("credential_types", map[string]interface{}{
+		"name": "test", "kind": "cloud",
+		"inputs": map[string]interface{}{"fields": []interface{}{map[string]interface{}{"id": "token", "label": "Token"}}},
+	})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_credential_type" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awx_credential_type.test", "name", "test"),
+					resource.TestCheckResourceAttr("data.awx_credential_type.test", "kind", "cloud"),
+					resource.TestCheckResourceAttr("data.awx_credential_type.test", "inputs", `{"fields":[{"id":"token","label":"Token"}]}`),
+				),
+			},
+			{
+				Config: config(`named_url = "Machine+ssh"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential_type.test", "id", "1"),
+			},
+			{
+				Config: config(`named_url = "test+cloud"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential_type.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("credential_types", id, map[string]interface{}{"description": "changed"})
+				},
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_credential_type.test", "description", "changed"),
+			},
+		},
+	})
+}
@@ -50,16 +50,25 @@ func dataSourceCredentials() *schema.Resource {
 
 func dataSourceCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 
-	creds, err := client.CredentialsService.ListCredentials(map[string]string{})
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credentials",
-			Detail:   "Unable to fetch credentials from AWX API",
-		})
-		return diags
+	// goawx ListCredentials reads the organizations endpoint
+	var creds []*awx.Credential
+	for page := 1; ; page++ {
+		result := new(awx.ListCredentialsResponse)
+		err := client.getJSON("/api/v2/credentials/", result, map[string]string{"page": strconv.Itoa(page)})
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to fetch credentials",
+				Detail:   "Unable to fetch credentials from AWX API",
+			})
+			return diags
+		}
+		creds = append(creds, result.Results...)
+		if next, _ := result.Next.(string); next == "" {
+			break
+		}
 	}
 
 	parsedCreds := make([]map[string]interface{}, 0)
@@ -71,7 +80,7 @@ func dataSourceCredentialsRead(ctx context.Context, d *schema.ResourceData, m in
 		})
 	}
 
-	err = d.Set("credentials", parsedCreds)
+	err := d.Set("credentials", parsedCreds)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,46 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceCredentials(t *testing.T) {
+	server := newTestServer(t)
+	server.SetPageSize(2)
+	var ids []int
+	for i := 0; i < 3; i++ {
+		ids = append(ids, server.Add("credentials", map[string]interface{}{
+			"name": fmt.Sprintf("test%d", i), "credential_type": 1,
+			"inputs": map[string]interface{}{"username": fmt.Sprintf("user%d", i)},
+		}))
+	}
+	config := testProviderConfig(server, `
+data "awx_credentials" "test" {}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awx_credentials.test", "credentials.#", "3"),
+					resource.TestCheckResourceAttr("data.awx_credentials.test", "credentials.2.id", strconv.Itoa(ids[2])),
+					resource.TestCheckResourceAttr("data.awx_credentials.test", "credentials.2.username", "user2"),
+					resource.TestCheckResourceAttr("data.awx_credentials.test", "credentials.2.kind", "ssh"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.Delete("credentials", ids[0])
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.awx_credentials.test", "credentials.#", "2"),
+			},
+		},
+	})
+}
@@ -17,7 +17,6 @@ import (
 	"context"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -37,16 +36,32 @@ func dataSourceInventoryGroup() *schema.Resource {
 				Computed: true,
 			},
 			"inventory_id": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"inventory_id", "named_url"},
 			},
+			"named_url": dataSourceNamedURLSchema("web++Servers++Engineering"),
 		},
 	}
 }
 
 func dataSourceInventoryGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
+	if diags := setIDFromNamedURL(d, m, "groups", "id"); diags.HasError() {
+		return diags
+	}
+	if _, okNamedURL := d.GetOk("named_url"); okNamedURL {
+		// the named URL holds the inventory, which may not be given
+		id := d.Get("id").(int)
+		group, err := client.GroupService.GetGroupByID(id, map[string]string{})
+		if err != nil {
+			return buildDiagNotFoundFail(diagElementInventoryGroupTitle, id, err)
+		}
+		d = setInventoryGroupResourceData(d, group)
+		return diags
+	}
 	params := make(map[string]string)
 	if groupName, okName := d.GetOk("name"); okName {
 		params["name"] = groupName.(string)
@@ -0,0 +1,44 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceInventoryGroup(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	id := server.Add("groups", map[string]interface{}{"name": "test", "inventory": inventoryID})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_inventory_group" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf("name = \"test\"\n  inventory_id = %d", inventoryID)),
+				Check:  resource.TestCheckResourceAttr("data.awx_inventory_group.test", "id", strconv.Itoa(id)),
+			},
+			{
+				Config: config(`named_url = "test++inventory++org"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_inventory_group.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("groups", id, map[string]interface{}{"name": "renamed"})
+				},
+				Config: config(fmt.Sprintf("id = %d\n  inventory_id = %d", id, inventoryID)),
+				Check:  resource.TestCheckResourceAttr("data.awx_inventory_group.test", "name", "renamed"),
+			},
+		},
+	})
+}
@@ -51,7 +51,7 @@ func dataSourceInventoryRole() *schema.Resource {
 
 func dataSourceInventoryRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	params := make(map[string]string)
 
 	inv_id := d.Get("inventory_id").(int)
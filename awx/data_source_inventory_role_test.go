@@ -0,0 +1,42 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceInventoryRole(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	config := func(lookup string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+data "awx_inventory_role" "test" {
+  inventory_id = %d
+  %s
+}
+`, inventoryID, lookup))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "Use"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_inventory_role.test", "id", strconv.Itoa(server.Role("inventories", inventoryID, "use_role"))),
+			},
+			{
+				Config: config(fmt.Sprintf("id = %d", server.Role("inventories", inventoryID, "admin_role"))),
+				Check:  resource.TestCheckResourceAttr("data.awx_inventory_role.test", "name", "Admin"),
+			},
+			{
+				Config:      config(`name = "Unknown"`),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
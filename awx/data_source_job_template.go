@@ -37,13 +37,17 @@ func dataSourceJobTemplate() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"named_url": dataSourceNamedURLSchema("Deploy++Engineering"),
 		},
 	}
 }
 
 func dataSourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
+	if diags := setIDFromNamedURL(d, m, "job_templates", "id"); diags.HasError() {
+		return diags
+	}
 	params := make(map[string]string)
 	if groupName, okName := d.GetOk("name"); okName {
 		params["name"] = groupName.(string)
@@ -103,3 +107,35 @@ func dataSourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m in
 		len(jobTemplate),
 	)
 }
+
+func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *schema.ResourceData {
+	d.Set("allow_simultaneous", r.AllowSimultaneous)
+	d.Set("ask_credential_on_launch", r.AskCredentialOnLaunch)
+	d.Set("ask_job_type_on_launch", r.AskJobTypeOnLaunch)
+	d.Set("ask_limit_on_launch", r.AskLimitOnLaunch)
+	d.Set("ask_skip_tags_on_launch", r.AskSkipTagsOnLaunch)
+	d.Set("ask_tags_on_launch", r.AskTagsOnLaunch)
+	d.Set("ask_variables_on_launch", r.AskVariablesOnLaunch)
+	d.Set("description", r.Description)
+	d.Set("extra_vars", normalizeJsonYaml(r.ExtraVars))
+	d.Set("force_handlers", r.ForceHandlers)
+	d.Set("forks", r.Forks)
+	d.Set("host_config_key", r.HostConfigKey)
+	d.Set("inventory_id", r.Inventory)
+	d.Set("job_tags", r.JobTags)
+	d.Set("job_type", r.JobType)
+	d.Set("diff_mode", r.DiffMode)
+	d.Set("custom_virtualenv", r.CustomVirtualenv)
+	d.Set("limit", r.Limit)
+	d.Set("name", r.Name)
+	d.Set("become_enabled", r.BecomeEnabled)
+	d.Set("use_fact_cache", r.UseFactCache)
+	d.Set("playbook", r.Playbook)
+	d.Set("project_id", r.Project)
+	d.Set("skip_tags", r.SkipTags)
+	d.Set("start_at_task", r.StartAtTask)
+	d.Set("survey_enabled", r.SurveyEnabled)
+	d.Set("verbosity", r.Verbosity)
+	d.SetId(strconv.Itoa(r.ID))
+	return d
+}
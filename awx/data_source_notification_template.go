@@ -16,7 +16,6 @@ import (
 	"context"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -35,13 +34,17 @@ func dataSourceNotificationTemplate() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"named_url": dataSourceNamedURLSchema("Slack++Engineering"),
 		},
 	}
 }
 
 func dataSourceNotificationTemplatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
+	if diags := setIDFromNamedURL(d, m, "notification_templates", "id"); diags.HasError() {
+		return diags
+	}
 	params := make(map[string]string)
 	if groupName, okName := d.GetOk("name"); okName {
 		params["name"] = groupName.(string)
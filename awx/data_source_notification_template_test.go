@@ -0,0 +1,43 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceNotificationTemplate(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	id := server.Add("notification_templates", map[string]interface{}{"name": "test", "organization": orgID, "notification_type": "slack"})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_notification_template" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "test"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_notification_template.test", "id", strconv.Itoa(id)),
+			},
+			{
+				Config: config(`named_url = "test++org"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_notification_template.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("notification_templates", id, map[string]interface{}{"name": "renamed"})
+				},
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_notification_template.test", "name", "renamed"),
+			},
+		},
+	})
+}
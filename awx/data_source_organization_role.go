@@ -51,7 +51,7 @@ func dataSourceOrganizationRole() *schema.Resource {
 
 func dataSourceOrganizationRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	params := make(map[string]string)
 
 	org_id := d.Get("organization_id").(int)
@@ -0,0 +1,41 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceOrganizationRole(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(lookup string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+data "awx_organization_role" "test" {
+  organization_id = %d
+  %s
+}
+`, orgID, lookup))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "Member"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_organization_role.test", "id", strconv.Itoa(server.Role("organizations", orgID, "member_role"))),
+			},
+			{
+				Config: config(fmt.Sprintf("id = %d", server.Role("organizations", orgID, "admin_role"))),
+				Check:  resource.TestCheckResourceAttr("data.awx_organization_role.test", "name", "Admin"),
+			},
+			{
+				Config:      config(`name = "Unknown"`),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceOrganization(t *testing.T) {
+	server := newTestServer(t)
+	id := server.Add("organizations", map[string]interface{}{"name": "test"})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_organization" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "test"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_organization.test", "id", strconv.Itoa(id)),
+			},
+			{
+				Config: config(`named_url = "test"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_organization.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("organizations", id, map[string]interface{}{"name": "renamed"})
+				},
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_organization.test", "name", "renamed"),
+			},
+		},
+	})
+}
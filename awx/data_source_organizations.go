@@ -15,7 +15,6 @@ import (
 	"strconv"
 	"time"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -46,7 +45,7 @@ func dataSourceOrganizations() *schema.Resource {
 
 func dataSourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 
 	parsedOrgs := make([]map[string]interface{}, 0)
 
@@ -0,0 +1,40 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceOrganizations(t *testing.T) {
+	server := newTestServer(t)
+	server.SetPageSize(2)
+	var ids []int
+	for i := 0; i < 3; i++ {
+		ids = append(ids, server.Add("organizations", map[string]interface{}{"name": fmt.Sprintf("org%d", i)}))
+	}
+	config := testProviderConfig(server, `
+data "awx_organizations" "test" {}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awx_organizations.test", "organizations.#", "3"),
+					resource.TestCheckResourceAttr("data.awx_organizations.test", "organizations.2.name", "org2"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.Update("organizations", ids[2], map[string]interface{}{"name": "renamed"})
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.awx_organizations.test", "organizations.2.name", "renamed"),
+			},
+		},
+	})
+}
@@ -51,7 +51,7 @@ func dataSourceProjectRole() *schema.Resource {
 
 func dataSourceProjectRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	params := make(map[string]string)
 
 	proj_id := d.Get("project_id").(int)
@@ -0,0 +1,42 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceProjectRole(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	config := func(lookup string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+data "awx_project_role" "test" {
+  project_id = %d
+  %s
+}
+`, projectID, lookup))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "Use"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_project_role.test", "id", strconv.Itoa(server.Role("projects", projectID, "use_role"))),
+			},
+			{
+				Config: config(fmt.Sprintf("id = %d", server.Role("projects", projectID, "update_role"))),
+				Check:  resource.TestCheckResourceAttr("data.awx_project_role.test", "name", "Update"),
+			},
+			{
+				Config:      config(`name = "Unknown"`),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceSchedule(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml"})
+	id := server.Add("schedules", map[string]interface{}{"name": "test", "rrule": "DTSTART;TZID=UTC:20230101T000000 RRULE:FREQ=DAILY;INTERVAL=1", "unified_job_template": jobTemplateID})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_schedule" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "test"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_schedule.test", "id", strconv.Itoa(id)),
+			},
+			{
+				Config: config(`named_url = "test++job++org"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_schedule.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("schedules", id, map[string]interface{}{"name": "renamed"})
+				},
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_schedule.test", "name", "renamed"),
+			},
+		},
+	})
+}
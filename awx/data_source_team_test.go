@@ -0,0 +1,43 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceTeam(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	id := server.Add("teams", map[string]interface{}{"name": "test", "organization": orgID})
+	config := func(lookup string) string {
+		return testProviderConfig(server, `
+data "awx_team" "test" {
+  `+lookup+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`name = "test"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_team.test", "id", strconv.Itoa(id)),
+			},
+			{
+				Config: config(`named_url = "test++org"`),
+				Check:  resource.TestCheckResourceAttr("data.awx_team.test", "id", strconv.Itoa(id)),
+			},
+			{
+				PreConfig: func() {
+					server.Update("teams", id, map[string]interface{}{"name": "renamed"})
+				},
+				Config: config(fmt.Sprintf("id = %d", id)),
+				Check:  resource.TestCheckResourceAttr("data.awx_team.test", "name", "renamed"),
+			},
+		},
+	})
+}
@@ -0,0 +1,265 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// appendSDKDiagnostics adds the diagnostics built by the SDKv2 helpers, e.g.
+// buildDiagAPIFail, to the diagnostics of a plugin framework resource.
+func appendSDKDiagnostics(target *fwdiag.Diagnostics, diags diag.Diagnostics) {
+	for _, d := range diags {
+		var attribute string
+		if len(d.AttributePath) > 0 {
+			if step, ok := d.AttributePath[0].(cty.GetAttrStep); ok {
+				attribute = step.Name
+			}
+		}
+		switch {
+		case d.Severity == diag.Error && attribute != "":
+			target.AddAttributeError(path.Root(attribute), d.Summary, d.Detail)
+		case d.Severity == diag.Error:
+			target.AddError(d.Summary, d.Detail)
+		case attribute != "":
+			target.AddAttributeWarning(path.Root(attribute), d.Summary, d.Detail)
+		default:
+			target.AddWarning(d.Summary, d.Detail)
+		}
+	}
+}
+
+// frameworkClient returns the client a plugin framework resource is
+// configured with, nil before the provider is configured.
+func frameworkClient(req resource.ConfigureRequest, resp *resource.ConfigureResponse) *awxClient {
+	if req.ProviderData == nil {
+		return nil
+	}
+	client, ok := req.ProviderData.(*awxClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *awxClient, got %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	return client
+}
+
+// frameworkStateUpgrader runs an SDKv2 state upgrader on the raw state of a
+// resource moved to the plugin framework, whose state may predate the move.
+func frameworkStateUpgrader(upgrader schema.StateUpgrader) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var rawState map[string]interface{}
+			if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+				resp.Diagnostics.AddError("Unable to read the prior state", err.Error())
+				return
+			}
+			rawState, err := upgrader.Upgrade(ctx, rawState, nil)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade the state", err.Error())
+				return
+			}
+			upgraded, err := json.Marshal(rawState)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to write the upgraded state", err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
+
+// jsonYAMLObjectValidator is validateJSONOrYAMLObject for plugin framework
+// attributes.
+type jsonYAMLObjectValidator struct{}
+
+func (v jsonYAMLObjectValidator) Description(ctx context.Context) string {
+	return "value must be a JSON or YAML object"
+}
+
+func (v jsonYAMLObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonYAMLObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, d := range validateJSONOrYAMLObject(req.ConfigValue.ValueString(), cty.Path{}) {
+		resp.Diagnostics.AddAttributeError(req.Path, d.Summary, d.Detail)
+	}
+}
+
+// jsonYAMLStringType is the type of the attributes holding JSON or YAML
+// variables, as extra_vars. Values decoding to the same variables are equal,
+// so the formatting AWX gives them back does not show as a change.
+type jsonYAMLStringType struct {
+	basetypes.StringType
+}
+
+func (t jsonYAMLStringType) Equal(o attr.Type) bool {
+	other, ok := o.(jsonYAMLStringType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t jsonYAMLStringType) String() string {
+	return "jsonYAMLStringType"
+}
+
+func (t jsonYAMLStringType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, fwdiag.Diagnostics) {
+	return jsonYAMLString{StringValue: in}, nil
+}
+
+func (t jsonYAMLStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	v, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return jsonYAMLString{StringValue: v.(basetypes.StringValue)}, nil
+}
+
+func (t jsonYAMLStringType) ValueType(ctx context.Context) attr.Value {
+	return jsonYAMLString{}
+}
+
+// jsonYAMLString is a value of jsonYAMLStringType.
+type jsonYAMLString struct {
+	basetypes.StringValue
+}
+
+func newJSONYAMLString(s string) jsonYAMLString {
+	return jsonYAMLString{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v jsonYAMLString) Type(ctx context.Context) attr.Type {
+	return jsonYAMLStringType{}
+}
+
+func (v jsonYAMLString) Equal(o attr.Value) bool {
+	other, ok := o.(jsonYAMLString)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v jsonYAMLString) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, fwdiag.Diagnostics) {
+	newValue, diags := newValuable.ToStringValue(ctx)
+	if diags.HasError() {
+		return false, diags
+	}
+	return variablesEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// variablesText returns the variables of text, or of m encoded to JSON when
+// the map is configured. It is getVariablesText for plugin framework
+// attributes.
+func variablesText(text jsonYAMLString, m types.Map) string {
+	if len(m.Elements()) == 0 {
+		return text.ValueString()
+	}
+	encoded, _ := json.Marshal(variablesFromMap(mapStrings(m)))
+	return string(encoded)
+}
+
+// setVariablesText sets the variables read from AWX to m when it holds a map,
+// to text otherwise. It is setVariablesOrMap for plugin framework attributes.
+func setVariablesText(text *jsonYAMLString, m *types.Map, value string) {
+	if len(m.Elements()) == 0 {
+		*text = newJSONYAMLString(value)
+		return
+	}
+	variables, err := decodeVariables(value)
+	if err != nil {
+		log.Printf("[WARN] unable to decode variables: %s", err)
+		*text = newJSONYAMLString(value)
+		*m = types.MapNull(types.StringType)
+		return
+	}
+	elements := make(map[string]attr.Value)
+	for key, value := range variablesToMap(variables, mapStrings(*m)) {
+		elements[key] = types.StringValue(value)
+	}
+	*m = types.MapValueMust(types.StringType, elements)
+}
+
+// mapStrings returns the values of a map of strings.
+func mapStrings(m types.Map) map[string]string {
+	values := make(map[string]string, len(m.Elements()))
+	for key, value := range m.Elements() {
+		if s, ok := value.(types.String); ok {
+			values[key] = s.ValueString()
+		}
+	}
+	return values
+}
+
+// modelGetter is a plan or a state.
+type modelGetter interface {
+	Get(ctx context.Context, target interface{}) fwdiag.Diagnostics
+}
+
+// getModelWithout reads a plan or a state into data, but for attribute whose
+// name differs between the resources sharing the model, which is returned.
+func getModelWithout(ctx context.Context, src modelGetter, attribute string, data interface{}) (types.Int64, fwdiag.Diagnostics) {
+	var object types.Object
+	diags := src.Get(ctx, &object)
+	if diags.HasError() {
+		return types.Int64Null(), diags
+	}
+	attributes := object.Attributes()
+	attributeTypes := object.AttributeTypes(ctx)
+	value := attributes[attribute].(types.Int64)
+	delete(attributes, attribute)
+	delete(attributeTypes, attribute)
+
+	model, d := types.ObjectValue(attributeTypes, attributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return value, diags
+	}
+	diags.Append(model.As(ctx, data, basetypes.ObjectAsOptions{})...)
+	return value, diags
+}
+
+// setModelWith writes data and the value of attribute to state, the reverse
+// of getModelWithout.
+func setModelWith(ctx context.Context, state *tfsdk.State, data interface{}, attribute string, value types.Int64) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	attributeTypes := state.Schema.Type().(attr.TypeWithAttributeTypes).AttributeTypes()
+	modelTypes := make(map[string]attr.Type, len(attributeTypes))
+	for name, t := range attributeTypes {
+		if name != attribute {
+			modelTypes[name] = t
+		}
+	}
+
+	model, d := types.ObjectValueFrom(ctx, modelTypes, data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	attributes := model.Attributes()
+	attributes[attribute] = value
+
+	object, d := types.ObjectValue(attributeTypes, attributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(state.Set(ctx, object)...)
+	return diags
+}
@@ -0,0 +1,119 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewProviderServer returns the factory of the provider server, serving the
+// SDKv2 and the plugin framework providers as one. The SDKv2 provider comes
+// first: it is configured first and builds the client the plugin framework
+// resources use.
+func NewProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := Provider()
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider(sdkProvider)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider serves the resources written on the plugin framework,
+// next to the SDKv2 provider behind terraform-plugin-mux. It has no settings
+// of its own: the SDKv2 provider is configured first and both share its client.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+// NewFrameworkProvider returns the plugin framework half of the provider,
+// sdkProvider being the one returned by Provider and served with it.
+func NewFrameworkProvider(sdkProvider *schema.Provider) provider.Provider {
+	return &frameworkProvider{sdkProvider: sdkProvider}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "awx"
+}
+
+// Schema mirrors the SDKv2 provider schema, mux requires both to be identical.
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes := make(map[string]providerschema.Attribute, len(p.sdkProvider.Schema))
+	for name, s := range p.sdkProvider.Schema {
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = providerschema.StringAttribute{
+				Optional:    s.Optional,
+				Required:    s.Required,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case schema.TypeBool:
+			attributes[name] = providerschema.BoolAttribute{
+				Optional:    s.Optional,
+				Required:    s.Required,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case schema.TypeInt:
+			attributes[name] = providerschema.Int64Attribute{
+				Optional:    s.Optional,
+				Required:    s.Required,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case schema.TypeFloat:
+			attributes[name] = providerschema.Float64Attribute{
+				Optional:    s.Optional,
+				Required:    s.Required,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		default:
+			resp.Diagnostics.AddError(
+				"Unsupported provider attribute",
+				fmt.Sprintf("The provider attribute %s of type %s cannot be served by the plugin framework", name, s.Type),
+			)
+		}
+	}
+	resp.Schema = providerschema.Schema{Attributes: attributes}
+}
+
+// Configure hands the client built by the SDKv2 provider to the framework
+// resources. It is nil when that configuration failed, which Terraform
+// already reports.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	client, ok := p.sdkProvider.Meta().(*awxClient)
+	if !ok {
+		return
+	}
+	resp.ResourceData = client
+	resp.DataSourceData = client
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newJobTemplateResource,
+		newJobTemplateSurveySpecResource,
+		newWorkflowJobTemplateNodeResource,
+		newWorkflowJobTemplateNodeAlwaysResource,
+		newWorkflowJobTemplateNodeFailureResource,
+		newWorkflowJobTemplateNodeSuccessResource,
+		newWorkflowJobTemplateSurveySpecResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
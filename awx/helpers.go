@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
-	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"gopkg.in/yaml.v2"
@@ -20,42 +24,131 @@ const (
 	diagElementHostTitle            = "Host"
 )
 
-func resourceJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.JobTemplateService
-	id, diags := convertStateIDToNummeric("Delete JobTemplate", d)
-	if diags.HasError() {
-		return diags
-	}
-	_, err := awxService.DeleteJobTemplate(id)
-	if err != nil {
-		return buildDiagDeleteFail(
-			"JobTemplate",
-			fmt.Sprintf(
-				"JobTemplateID %v, got %s ",
-				id, err.Error(),
-			),
-		)
-	}
-	d.SetId("")
-	return nil
-}
 func buildDiagCreateFail(tfMethode string, err error) diag.Diagnostics {
-	return buildDiagnosticsMessage(
+	return buildDiagAPIFail(
 		fmt.Sprintf("Unable to create %s", tfMethode),
-		"Unable to create %s got %s",
-		tfMethode, err.Error(),
+		err,
+		"Unable to create %s",
+		tfMethode,
 	)
 }
 func buildDiagUpdateFail(tfMethode string, id int, err error) diag.Diagnostics {
-	return buildDiagnosticsMessage(
+	return buildDiagAPIFail(
 		fmt.Sprintf("Unable to update %s", tfMethode),
-		"Unable to update %s with id %d: got %s",
-		tfMethode, id, err.Error(),
+		err,
+		"Unable to update %s with id %d",
+		tfMethode, id,
 	)
 }
 
+// awxFieldErrorRegexp matches the fields of an AWX validation error, which
+// goawx reports as `Errors:\n- <field>: [<messages>]`.
+var awxFieldErrorRegexp = regexp.MustCompile(`(?m)^- (\w+): \[(.*)\]$`)
+
+// apiFieldAttributes maps the AWX API fields to the attribute set from them
+// when the provider names it differently.
+var apiFieldAttributes = map[string]string{
+	"credential":            "credential_id",
+	"credential_type":       "credential_type_id",
+	"inventory":             "inventory_id",
+	"job_template":          "job_template_id",
+	"notification_template": "notification_template_id",
+	"organization":          "organization_id",
+	"project":               "project_id",
+	"source_credential":     "source",
+	"source_project":        "source_project_id",
+	"target_credential":     "target",
+	"unified_job_template":  "unified_job_template_id",
+	"workflow_job_template": "workflow_job_template_id",
+}
+
+// apiNonFieldErrors are the keys AWX reports errors under when they are not
+// tied to a single field.
+var apiNonFieldErrors = map[string]bool{
+	"__all__":          true,
+	"detail":           true,
+	"error":            true,
+	"non_field_errors": true,
+}
+
+// buildDiagAPIFail builds the diagnostics of a failed API call. A validation
+// error from AWX gives one diagnostic per rejected field, pointing at the
+// matching attribute so Terraform shows the offending configuration line.
+// Details start with the formatted detailsFormat.
+func buildDiagAPIFail(diagSummary string, err error, detailsFormat string, detailsVars ...interface{}) diag.Diagnostics {
+	details := fmt.Sprintf(detailsFormat, detailsVars...)
+
+	var diags diag.Diagnostics
+	if strings.HasPrefix(err.Error(), "Errors:") {
+		// goawx lists the fields in map order
+		matches := awxFieldErrorRegexp.FindAllStringSubmatch(err.Error(), -1)
+		sort.Slice(matches, func(i, j int) bool { return matches[i][1] < matches[j][1] })
+		for _, match := range matches {
+			field, message := match[1], match[2]
+			d := diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  diagSummary,
+				Detail:   fmt.Sprintf("%s, %s: %s", details, field, message),
+			}
+			if !apiNonFieldErrors[field] {
+				attribute := field
+				if a, ok := apiFieldAttributes[field]; ok {
+					attribute = a
+				}
+				d.AttributePath = cty.GetAttrPath(attribute)
+			}
+			diags = append(diags, d)
+		}
+	}
+	if len(diags) > 0 {
+		return diags
+	}
+	return buildDiagnosticsMessage(diagSummary, "%s: got %s", details, err.Error())
+}
+
+// scopeAPIFieldDiagnostics makes the diagnostics of the resources point at
+// attributes they have: apiFieldAttributes is shared by every resource, and
+// some name the attribute after the API field, e.g. the `inventory` of
+// schedules. The raw field is used then, or the resource itself when it has
+// neither attribute.
+func scopeAPIFieldDiagnostics(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range resources {
+		r := r
+		scope := func(diags diag.Diagnostics) diag.Diagnostics {
+			for i, d := range diags {
+				if len(d.AttributePath) != 1 {
+					continue
+				}
+				step, ok := d.AttributePath[0].(cty.GetAttrStep)
+				if !ok {
+					continue
+				}
+				if _, ok := r.Schema[step.Name]; ok {
+					continue
+				}
+				diags[i].AttributePath = nil
+				for field, attribute := range apiFieldAttributes {
+					if _, ok := r.Schema[field]; ok && attribute == step.Name {
+						diags[i].AttributePath = cty.GetAttrPath(field)
+					}
+				}
+			}
+			return diags
+		}
+		if create := r.CreateContext; create != nil {
+			r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				return scope(create(ctx, d, m))
+			}
+		}
+		if update := r.UpdateContext; update != nil {
+			r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				return scope(update(ctx, d, m))
+			}
+		}
+	}
+	return resources
+}
+
 func buildDiagNotFoundFail(tfMethode string, id int, err error) diag.Diagnostics {
 	return buildDiagnosticsMessage(
 		fmt.Sprintf("Unable to fetch %s", tfMethode),
@@ -64,6 +157,23 @@ func buildDiagNotFoundFail(tfMethode string, id int, err error) diag.Diagnostics
 	)
 }
 
+// isNotFoundError reports whether err comes from a 404 answer of the AWX API.
+func isNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "responsed with 404")
+}
+
+// removeFromStateIfNotFound clears the ID of a resource deleted outside of
+// Terraform, so the next plan re-creates it instead of failing. It reports
+// whether err was a 404, any other error is left to the caller.
+func removeFromStateIfNotFound(d *schema.ResourceData, tfElement string, err error) bool {
+	if !isNotFoundError(err) {
+		return false
+	}
+	log.Printf("[WARN] %s %s not found, removing it from the state", tfElement, d.Id())
+	d.SetId("")
+	return true
+}
+
 func buildDiagDeleteFail(tfMethode, details string) diag.Diagnostics {
 	return buildDiagnosticsMessage(
 		buildDiagDeleteFailSummary(tfMethode),
@@ -78,13 +188,17 @@ func buildDiagDeleteFailDetails(tfMethode, detailsString string) string {
 }
 
 func convertStateIDToNummeric(tfElement string, d *schema.ResourceData) (int, diag.Diagnostics) {
+	return convertIDToNummeric(tfElement, d.Id())
+}
+
+func convertIDToNummeric(tfElement string, stateID string) (int, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	id, err := strconv.Atoi(d.Id())
+	id, err := strconv.Atoi(stateID)
 	if err != nil {
 		return id, buildDiagnosticsMessage(
 			fmt.Sprintf("%s, State ID Not Converted", tfElement),
 			"Value in State %s is`t nummeric, %s",
-			d.Id(), err.Error(),
+			stateID, err.Error(),
 		)
 	}
 	return id, diags
@@ -104,7 +218,7 @@ func CredentialsServiceDeleteByID(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 
 	id, _ := strconv.Atoi(d.Id())
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	err := client.CredentialsService.DeleteCredentialsByID(id, map[string]string{})
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -120,7 +234,7 @@ func CredentialTypeServiceDeleteByID(ctx context.Context, d *schema.ResourceData
 	var diags diag.Diagnostics
 
 	id, _ := strconv.Atoi(d.Id())
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	err := client.CredentialTypeService.DeleteCredentialTypeByID(id, map[string]string{})
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -133,6 +247,30 @@ func CredentialTypeServiceDeleteByID(ctx context.Context, d *schema.ResourceData
 	return diags
 }
 
+// setJSONOnImport sets a JSON string attribute from the API value only when
+// the state holds none, as after an import. Otherwise the configured value is
+// kept: AWX masks secrets as `$encrypted$` and does not keep the key order.
+func setJSONOnImport(d *schema.ResourceData, attribute string, v interface{}) {
+	if d.Get(attribute).(string) != "" || v == nil {
+		return
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("[WARN] unable to encode %s: %s", attribute, err)
+		return
+	}
+	d.Set(attribute, string(encoded))
+}
+
+// setSecretInput sets an attribute from a credential input, but for the
+// secrets AWX answers as `$encrypted$`: the value in state is kept then.
+func setSecretInput(d *schema.ResourceData, attribute string, v interface{}) {
+	if v == "$encrypted$" {
+		return
+	}
+	d.Set(attribute, v)
+}
+
 func normalizeJsonYaml(s interface{}) string {
 	result := string("")
 	if j, ok := normalizeJsonOk(s); ok {
@@ -170,19 +308,48 @@ func normalizeYamlOk(s interface{}) (string, bool) {
 	return string(b[:]), true
 }
 
-func unmarshalYaml(str string) map[string]interface{} {
-	asMap := map[string]interface{}{}
-	err := yaml.Unmarshal([]byte(str), &asMap)
+// marshalYaml encodes v to YAML, as the extra_data AWX gives back as an
+// object.
+func marshalYaml(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	encoded, err := yaml.Marshal(v)
 	if err != nil {
-		asMap = nil
+		log.Printf("[WARN] unable to encode to YAML: %s", err)
+		return ""
 	}
-	return asMap
+	return string(encoded)
 }
 
-func marshalYaml(v interface{}) string {
-	extraDataBytes, err := yaml.Marshal(v)
-	if err != nil {
-		return string(extraDataBytes)
+// numericIDStateUpgrader converts the reference attributes a resource stored
+// as strings up to version, e.g. `inventory_id = "12"`, to numbers. Empty
+// strings, unset references, become 0.
+func numericIDStateUpgrader(version int, attributes ...string) schema.StateUpgrader {
+	attributeTypes := map[string]cty.Type{"id": cty.String}
+	for _, attribute := range attributes {
+		attributeTypes[attribute] = cty.String
+	}
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    cty.Object(attributeTypes),
+		Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			for _, attribute := range attributes {
+				s, ok := rawState[attribute].(string)
+				if !ok {
+					continue
+				}
+				if s == "" {
+					rawState[attribute] = 0
+					continue
+				}
+				v, err := strconv.Atoi(s)
+				if err != nil {
+					return nil, fmt.Errorf("unable to upgrade the state of %s: %s is %q", rawState["id"], attribute, s)
+				}
+				rawState[attribute] = v
+			}
+			return rawState, nil
+		},
 	}
-	return ""
 }
@@ -0,0 +1,491 @@
+package fakeawx
+
+// unifiedJobTemplates are the collections sharing the unified job template
+// IDs, referenced by schedules and workflow nodes.
+var unifiedJobTemplates = []string{"job_templates", "workflow_job_templates", "projects", "inventory_sources"}
+
+// unifiedJobs are the collections sharing the unified job IDs.
+var unifiedJobs = []string{"jobs", "workflow_jobs", "project_updates", "inventory_updates"}
+
+// unified is the pseudo collection references to any unified job template
+// point to.
+const unified = "unified_job_templates"
+
+// collection describes how the fake handles the objects of an API
+// collection, e.g. `/api/v2/job_templates/`.
+type collection struct {
+	// typ is the type AWX gives the objects, e.g. `job_template`.
+	typ string
+	// required fields are rejected when missing or blank on creation.
+	required []string
+	// references maps the fields holding an ID to the collection they point to.
+	references map[string]string
+	// owners are the references whose deletion deletes the object, the other
+	// references are set to null.
+	owners []string
+	// unique fields identify an object together, e.g. a name in an organization.
+	unique []string
+	// namedURL lists the fields joined with `+` in the AWX named URL of an
+	// object, followed with `++` by the named URL of each reference of parents.
+	namedURL []string
+	parents  []string
+	// choices restricts the values accepted by some fields.
+	choices map[string][]interface{}
+	// defaults are the values of the fields not given on creation.
+	defaults map[string]interface{}
+	// roles are created with each object and listed in its summary fields.
+	roles []string
+	// associations maps the related endpoints linking objects, e.g.
+	// `credentials` under job templates, to the collection they list.
+	associations map[string]string
+	// children maps the related endpoints listing the objects referencing
+	// this one, e.g. `schedules` under workflow job templates, to their
+	// collection and reference.
+	children map[string]child
+}
+
+type child struct {
+	collection string
+	reference  string
+}
+
+var roleNames = map[string]string{
+	"adhoc_role":                       "Ad Hoc",
+	"admin_role":                       "Admin",
+	"approval_role":                    "Approve",
+	"auditor_role":                     "Auditor",
+	"credential_admin_role":            "Credential Admin",
+	"execute_role":                     "Execute",
+	"execution_environment_admin_role": "Execution Environment Admin",
+	"inventory_admin_role":             "Inventory Admin",
+	"job_template_admin_role":          "Job Template Admin",
+	"member_role":                      "Member",
+	"notification_admin_role":          "Notification Admin",
+	"project_admin_role":               "Project Admin",
+	"read_role":                        "Read",
+	"update_role":                      "Update",
+	"use_role":                         "Use",
+	"workflow_admin_role":              "Workflow Admin",
+}
+
+var notificationAssociations = map[string]string{
+	"notification_templates_started": "notification_templates",
+	"notification_templates_success": "notification_templates",
+	"notification_templates_error":   "notification_templates",
+}
+
+var collections = map[string]*collection{
+	"organizations": {
+		typ:      "organization",
+		required: []string{"name"},
+		references: map[string]string{
+			"default_environment": "execution_environments",
+		},
+		unique:   []string{"name"},
+		namedURL: []string{"name"},
+		defaults: map[string]interface{}{"description": "", "max_hosts": 0, "custom_virtualenv": nil},
+		roles: []string{
+			"admin_role", "execute_role", "project_admin_role", "inventory_admin_role",
+			"credential_admin_role", "workflow_admin_role", "notification_admin_role",
+			"job_template_admin_role", "execution_environment_admin_role", "auditor_role",
+			"member_role", "read_role", "approval_role",
+		},
+		associations: mergeAssociations(notificationAssociations, map[string]string{
+			"galaxy_credentials":               "credentials",
+			"instance_groups":                  "instance_groups",
+			"notification_templates_approvals": "notification_templates",
+		}),
+		children: map[string]child{
+			"teams":       {"teams", "organization"},
+			"inventories": {"inventories", "organization"},
+			"projects":    {"projects", "organization"},
+		},
+	},
+	"teams": {
+		typ:          "team",
+		required:     []string{"name", "organization"},
+		references:   map[string]string{"organization": "organizations"},
+		owners:       []string{"organization"},
+		unique:       []string{"name", "organization"},
+		namedURL:     []string{"name"},
+		parents:      []string{"organization"},
+		defaults:     map[string]interface{}{"description": ""},
+		roles:        []string{"admin_role", "member_role", "read_role"},
+		associations: map[string]string{"roles": "roles", "users": "users"},
+	},
+	"users": {
+		typ:      "user",
+		required: []string{"username"},
+		unique:   []string{"username"},
+		namedURL: []string{"username"},
+		defaults: map[string]interface{}{
+			"first_name": "", "last_name": "", "email": "",
+			"is_superuser": false, "is_system_auditor": false,
+		},
+		associations: map[string]string{"roles": "roles"},
+	},
+	"roles": {
+		typ: "role",
+	},
+	"credential_types": {
+		typ:      "credential_type",
+		required: []string{"name", "kind"},
+		unique:   []string{"name", "kind"},
+		namedURL: []string{"name", "kind"},
+		choices: map[string][]interface{}{
+			"kind": {"ssh", "vault", "net", "scm", "cloud", "registry", "token", "insights", "external", "kubernetes", "galaxy", "cryptography"},
+		},
+		defaults: map[string]interface{}{
+			"description": "", "namespace": nil, "managed": false,
+			"inputs": map[string]interface{}{}, "injectors": map[string]interface{}{},
+		},
+	},
+	"credentials": {
+		typ:      "credential",
+		required: []string{"name", "credential_type"},
+		references: map[string]string{
+			"organization":    "organizations",
+			"credential_type": "credential_types",
+		},
+		unique:   []string{"name", "organization", "credential_type"},
+		namedURL: []string{"name"},
+		parents:  []string{"credential_type", "organization"},
+		defaults: map[string]interface{}{"description": "", "inputs": map[string]interface{}{}},
+		roles:    []string{"admin_role", "use_role", "read_role"},
+	},
+	"credential_input_sources": {
+		typ:      "credential_input_source",
+		required: []string{"target_credential", "source_credential", "input_field_name"},
+		references: map[string]string{
+			"target_credential": "credentials",
+			"source_credential": "credentials",
+		},
+		owners:   []string{"target_credential", "source_credential"},
+		unique:   []string{"target_credential", "input_field_name"},
+		defaults: map[string]interface{}{"description": "", "metadata": map[string]interface{}{}},
+	},
+	"execution_environments": {
+		typ:      "execution_environment",
+		required: []string{"name", "image"},
+		references: map[string]string{
+			"organization": "organizations",
+			"credential":   "credentials",
+		},
+		namedURL: []string{"name"},
+		choices: map[string][]interface{}{
+			"pull": {"", "always", "missing", "never"},
+		},
+		defaults: map[string]interface{}{"description": "", "pull": ""},
+	},
+	"instance_groups": {
+		typ:      "instance_group",
+		required: []string{"name"},
+		references: map[string]string{
+			"credential": "credentials",
+		},
+		unique:   []string{"name"},
+		namedURL: []string{"name"},
+		defaults: map[string]interface{}{
+			"is_container_group": false, "policy_instance_percentage": 0,
+			"policy_instance_minimum": 0, "policy_instance_list": []interface{}{},
+			"pod_spec_override": "",
+		},
+		roles: []string{"admin_role", "use_role", "read_role"},
+	},
+	"inventories": {
+		typ:        "inventory",
+		required:   []string{"name", "organization"},
+		references: map[string]string{"organization": "organizations"},
+		unique:     []string{"name", "organization"},
+		namedURL:   []string{"name"},
+		parents:    []string{"organization"},
+		choices: map[string][]interface{}{
+			"kind": {"", "smart", "constructed"},
+		},
+		defaults: map[string]interface{}{
+			"description": "", "kind": "", "host_filter": nil, "variables": "",
+		},
+		roles: []string{"admin_role", "update_role", "adhoc_role", "use_role", "read_role"},
+		associations: map[string]string{
+			"instance_groups": "instance_groups",
+		},
+		children: map[string]child{
+			"hosts":             {"hosts", "inventory"},
+			"groups":            {"groups", "inventory"},
+			"inventory_sources": {"inventory_sources", "inventory"},
+		},
+	},
+	"hosts": {
+		typ:        "host",
+		required:   []string{"name", "inventory"},
+		references: map[string]string{"inventory": "inventories"},
+		owners:     []string{"inventory"},
+		unique:     []string{"name", "inventory"},
+		namedURL:   []string{"name"},
+		parents:    []string{"inventory"},
+		defaults: map[string]interface{}{
+			"description": "", "enabled": true, "instance_id": "", "variables": "",
+		},
+		associations: map[string]string{"groups": "groups"},
+	},
+	"groups": {
+		typ:        "group",
+		required:   []string{"name", "inventory"},
+		references: map[string]string{"inventory": "inventories"},
+		owners:     []string{"inventory"},
+		unique:     []string{"name", "inventory"},
+		namedURL:   []string{"name"},
+		parents:    []string{"inventory"},
+		defaults:   map[string]interface{}{"description": "", "variables": ""},
+		associations: map[string]string{
+			"hosts":    "hosts",
+			"children": "groups",
+		},
+	},
+	"inventory_sources": {
+		typ:      "inventory_source",
+		required: []string{"name", "inventory"},
+		references: map[string]string{
+			"inventory":             "inventories",
+			"source_project":        "projects",
+			"credential":            "credentials",
+			"execution_environment": "execution_environments",
+		},
+		owners:   []string{"inventory"},
+		unique:   []string{"name", "inventory"},
+		namedURL: []string{"name"},
+		parents:  []string{"inventory"},
+		choices: map[string][]interface{}{
+			"source": {"file", "constructed", "scm", "ec2", "gce", "azure_rm", "vmware", "satellite6", "openstack", "rhv", "controller", "insights"},
+		},
+		defaults: map[string]interface{}{
+			"description": "", "source": "scm", "source_path": "", "source_vars": "",
+			"enabled_var": "", "enabled_value": "", "host_filter": "",
+			"overwrite": false, "overwrite_vars": false, "update_on_launch": false,
+			"update_cache_timeout": 0, "verbosity": 1, "timeout": 0,
+		},
+		associations: notificationAssociations,
+		children: map[string]child{
+			"schedules": {"schedules", "unified_job_template"},
+		},
+	},
+	"projects": {
+		typ:      "project",
+		required: []string{"name"},
+		references: map[string]string{
+			"organization":                    "organizations",
+			"credential":                      "credentials",
+			"default_environment":             "execution_environments",
+			"signature_validation_credential": "credentials",
+		},
+		unique:   []string{"name", "organization"},
+		namedURL: []string{"name"},
+		parents:  []string{"organization"},
+		choices: map[string][]interface{}{
+			"scm_type": {"", "git", "svn", "insights", "archive"},
+		},
+		defaults: map[string]interface{}{
+			"description": "", "local_path": "", "scm_type": "", "scm_url": "",
+			"scm_branch": "", "scm_refspec": "", "scm_clean": false,
+			"scm_delete_on_update": false, "scm_update_on_launch": false,
+			"scm_update_cache_timeout": 0, "allow_override": false, "timeout": 0,
+			"status": "successful",
+		},
+		roles:        []string{"admin_role", "use_role", "update_role", "read_role"},
+		associations: notificationAssociations,
+		children: map[string]child{
+			"project_updates": {"project_updates", "project"},
+			"schedules":       {"schedules", "unified_job_template"},
+		},
+	},
+	"project_updates": {
+		typ:        "project_update",
+		references: map[string]string{"project": "projects"},
+		owners:     []string{"project"},
+	},
+	"inventory_updates": {
+		typ:        "inventory_update",
+		references: map[string]string{"inventory_source": "inventory_sources"},
+		owners:     []string{"inventory_source"},
+	},
+	"job_templates": {
+		typ:      "job_template",
+		required: []string{"name", "project", "playbook"},
+		references: map[string]string{
+			"organization":          "organizations",
+			"project":               "projects",
+			"inventory":             "inventories",
+			"execution_environment": "execution_environments",
+			"webhook_credential":    "credentials",
+		},
+		unique:   []string{"name", "organization"},
+		namedURL: []string{"name"},
+		parents:  []string{"organization"},
+		choices: map[string][]interface{}{
+			"job_type":  {"run", "check"},
+			"verbosity": {0, 1, 2, 3, 4, 5},
+		},
+		defaults: map[string]interface{}{
+			"description": "", "job_type": "run", "forks": 0, "limit": "",
+			"verbosity": 0, "extra_vars": "", "job_tags": "", "skip_tags": "",
+			"timeout": 0, "force_handlers": false, "use_fact_cache": false,
+			"host_config_key": "", "start_at_task": "", "become_enabled": false,
+			"diff_mode": false, "allow_simultaneous": false, "custom_virtualenv": nil,
+			"job_slice_count": 1, "scm_branch": "", "survey_enabled": false,
+			"ask_scm_branch_on_launch": false, "ask_diff_mode_on_launch": false,
+			"ask_variables_on_launch": false, "ask_limit_on_launch": false,
+			"ask_tags_on_launch": false, "ask_skip_tags_on_launch": false,
+			"ask_job_type_on_launch": false, "ask_verbosity_on_launch": false,
+			"ask_inventory_on_launch": false, "ask_credential_on_launch": false,
+			"ask_execution_environment_on_launch": false, "ask_labels_on_launch": false,
+			"ask_forks_on_launch": false, "ask_job_slice_count_on_launch": false,
+			"ask_timeout_on_launch": false, "ask_instance_groups_on_launch": false,
+			"webhook_service": "", "prevent_instance_group_fallback": false,
+		},
+		roles: []string{"admin_role", "execute_role", "read_role"},
+		associations: mergeAssociations(notificationAssociations, map[string]string{
+			"credentials":     "credentials",
+			"instance_groups": "instance_groups",
+		}),
+		children: map[string]child{
+			"jobs":      {"jobs", "job_template"},
+			"schedules": {"schedules", "unified_job_template"},
+		},
+	},
+	"jobs": {
+		typ: "job",
+		references: map[string]string{
+			"job_template": "job_templates",
+			"inventory":    "inventories",
+		},
+	},
+	"workflow_job_templates": {
+		typ:      "workflow_job_template",
+		required: []string{"name"},
+		references: map[string]string{
+			"organization":       "organizations",
+			"inventory":          "inventories",
+			"webhook_credential": "credentials",
+		},
+		unique:   []string{"name", "organization"},
+		namedURL: []string{"name"},
+		parents:  []string{"organization"},
+		defaults: map[string]interface{}{
+			"description": "", "extra_vars": "", "survey_enabled": false,
+			"allow_simultaneous": false, "ask_variables_on_launch": false,
+			"ask_inventory_on_launch": false, "ask_scm_branch_on_launch": false,
+			"ask_limit_on_launch": false, "limit": nil, "scm_branch": nil,
+			"webhook_service": "",
+		},
+		roles: []string{"admin_role", "execute_role", "read_role", "approval_role"},
+		associations: mergeAssociations(notificationAssociations, map[string]string{
+			"notification_templates_approvals": "notification_templates",
+		}),
+		children: map[string]child{
+			"workflow_nodes": {"workflow_job_template_nodes", "workflow_job_template"},
+			"workflow_jobs":  {"workflow_jobs", "workflow_job_template"},
+			"schedules":      {"schedules", "unified_job_template"},
+		},
+	},
+	"workflow_jobs": {
+		typ:        "workflow_job",
+		references: map[string]string{"workflow_job_template": "workflow_job_templates"},
+	},
+	"workflow_job_template_nodes": {
+		typ:      "workflow_job_template_node",
+		required: []string{"workflow_job_template"},
+		references: map[string]string{
+			"workflow_job_template": "workflow_job_templates",
+			"unified_job_template":  unified,
+			"inventory":             "inventories",
+			"execution_environment": "execution_environments",
+		},
+		owners:   []string{"workflow_job_template"},
+		unique:   []string{"identifier", "workflow_job_template"},
+		namedURL: []string{"identifier"},
+		parents:  []string{"workflow_job_template"},
+		defaults: map[string]interface{}{
+			"extra_data": map[string]interface{}{}, "scm_branch": nil, "job_type": nil,
+			"job_tags": nil, "skip_tags": nil, "limit": nil, "diff_mode": nil,
+			"verbosity": nil, "all_parents_must_converge": false,
+		},
+		associations: map[string]string{
+			"success_nodes": "workflow_job_template_nodes",
+			"failure_nodes": "workflow_job_template_nodes",
+			"always_nodes":  "workflow_job_template_nodes",
+			"credentials":   "credentials",
+		},
+	},
+	"notification_templates": {
+		typ:        "notification_template",
+		required:   []string{"name", "organization", "notification_type"},
+		references: map[string]string{"organization": "organizations"},
+		unique:     []string{"name", "organization"},
+		namedURL:   []string{"name"},
+		parents:    []string{"organization"},
+		choices: map[string][]interface{}{
+			"notification_type": {"email", "grafana", "irc", "mattermost", "pagerduty", "rocketchat", "slack", "twilio", "webhook"},
+		},
+		defaults: map[string]interface{}{
+			"description": "", "notification_configuration": map[string]interface{}{},
+			"messages": nil,
+		},
+	},
+	"schedules": {
+		typ:      "schedule",
+		required: []string{"name", "rrule", "unified_job_template"},
+		references: map[string]string{
+			"unified_job_template": unified,
+			"inventory":            "inventories",
+		},
+		owners:   []string{"unified_job_template"},
+		unique:   []string{"name", "unified_job_template"},
+		namedURL: []string{"name"},
+		parents:  []string{"unified_job_template"},
+		defaults: map[string]interface{}{
+			"description": "", "enabled": true, "extra_data": map[string]interface{}{},
+		},
+	},
+}
+
+func mergeAssociations(maps ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// managedCredentialTypes are the credential types AWX ships with, under the
+// IDs the provider relies on. Only their secret input fields are declared.
+var managedCredentialTypes = []map[string]interface{}{
+	{"id": 1, "name": "Machine", "kind": "ssh", "namespace": "ssh", "inputs": secretFields("password", "ssh_key_data", "ssh_key_unlock", "become_password")},
+	{"id": 2, "name": "Source Control", "kind": "scm", "namespace": "scm", "inputs": secretFields("password", "ssh_key_data", "ssh_key_unlock")},
+	{"id": 3, "name": "Vault", "kind": "vault", "namespace": "vault", "inputs": secretFields("vault_password")},
+	{"id": 4, "name": "Network", "kind": "net", "namespace": "net", "inputs": secretFields("password", "ssh_key_data", "ssh_key_unlock", "authorize_password")},
+	{"id": 5, "name": "Amazon Web Services", "kind": "cloud", "namespace": "aws", "inputs": secretFields("password", "security_token")},
+	{"id": 6, "name": "OpenStack", "kind": "cloud", "namespace": "openstack", "inputs": secretFields("password")},
+	{"id": 7, "name": "VMware vCenter", "kind": "cloud", "namespace": "vmware", "inputs": secretFields("password")},
+	{"id": 8, "name": "Red Hat Satellite 6", "kind": "cloud", "namespace": "satellite6", "inputs": secretFields("password")},
+	{"id": 10, "name": "Google Compute Engine", "kind": "cloud", "namespace": "gce", "inputs": secretFields("ssh_key_data")},
+	{"id": 11, "name": "Microsoft Azure Resource Manager", "kind": "cloud", "namespace": "azure_rm", "inputs": secretFields("password", "secret")},
+	{"id": 12, "name": "GitLab Personal Access Token", "kind": "token", "namespace": "gitlab_token", "inputs": secretFields("token")},
+	{"id": 13, "name": "GitHub Personal Access Token", "kind": "token", "namespace": "github_token", "inputs": secretFields("token")},
+	{"id": 15, "name": "Container Registry", "kind": "registry", "namespace": "registry", "inputs": secretFields("password")},
+	{"id": 17, "name": "Insights", "kind": "insights", "namespace": "insights", "inputs": secretFields("password")},
+	{"id": 18, "name": "Ansible Galaxy/Automation Hub API Token", "kind": "galaxy", "namespace": "galaxy_api_token", "inputs": secretFields("token")},
+	{"id": 19, "name": "Microsoft Azure Key Vault", "kind": "external", "namespace": "azure_kv", "inputs": secretFields("secret")},
+	{"id": 20, "name": "HashiCorp Vault Secret Lookup", "kind": "external", "namespace": "hashivault_kv", "inputs": secretFields("token", "secret_id")},
+}
+
+// secretFields builds the inputs of a credential type declaring the given
+// fields as secret.
+func secretFields(ids ...string) map[string]interface{} {
+	fields := make([]interface{}, len(ids))
+	for i, id := range ids {
+		fields[i] = map[string]interface{}{"id": id, "type": "string", "secret": true}
+	}
+	return map[string]interface{}{"fields": fields}
+}
@@ -0,0 +1,1350 @@
+// Package fakeawx is an in-process fake of the AWX API, serving the
+// `/api/v2/` endpoints goawx and the provider call from memory. Objects are
+// validated and rendered the way AWX does, so the provider can be unit tested
+// without a controller:
+//
+//	server := fakeawx.New()
+//	defer server.Close()
+//	orgID := server.Add("organizations", map[string]interface{}{"name": "Default"})
+//
+// Like AWX, the secret inputs of credentials and the password defaults of
+// survey specs are answered as `$encrypted$`, and keep their value when
+// posted so.
+package fakeawx
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIPath is the base path the fake serves the API under.
+const APIPath = "/api/v2/"
+
+// DefaultVersion is the controller version reported by a new Server.
+const DefaultVersion = "23.3.1"
+
+// Server is a running fake AWX, its URL is the hostname to configure the
+// provider with.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// version is returned by `/api/v2/ping/` and `/api/v2/config/`.
+	version  string
+	pageSize int
+	// lastIDs holds the last ID given in each sequence, collections sharing
+	// IDs in AWX share a sequence.
+	lastIDs  map[string]int
+	objects  map[string]map[int]map[string]interface{}
+	links    map[link]map[int]bool
+	roles    map[string]map[int]map[string]int
+	settings map[string]map[string]interface{}
+	// surveys holds the survey specs of the job templates and workflow job
+	// templates.
+	surveys map[string]map[int]map[string]interface{}
+}
+
+// link identifies the objects associated to an object through one of its
+// related endpoints, e.g. the credentials of a job template.
+type link struct {
+	collection string
+	id         int
+	related    string
+}
+
+// New starts a fake AWX holding the managed credential types, an admin user
+// and the default settings.
+func New() *Server {
+	s := &Server{
+		version:  DefaultVersion,
+		pageSize: 25,
+		lastIDs:  make(map[string]int),
+		objects:  make(map[string]map[int]map[string]interface{}),
+		links:    make(map[link]map[int]bool),
+		roles:    make(map[string]map[int]map[string]int),
+		settings: defaultSettings(),
+		surveys:  make(map[string]map[int]map[string]interface{}),
+	}
+	for _, credentialType := range managedCredentialTypes {
+		fields := copyValue(credentialType).(map[string]interface{})
+		fields["managed"] = true
+		s.insert("credential_types", fields)
+	}
+	s.insert("users", map[string]interface{}{"username": "admin", "is_superuser": true})
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetVersion changes the controller version the fake reports.
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version = version
+}
+
+// SetPageSize changes the default number of objects per page of the lists.
+func (s *Server) SetPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = size
+}
+
+// Add creates an object the way a POST to its collection does and returns
+// its ID. It panics when AWX would reject the object.
+func (s *Server) Add(collection string, fields map[string]interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, errs := s.create(collection, normalize(copyValue(fields)).(map[string]interface{}))
+	if errs != nil {
+		panic(fmt.Sprintf("fakeawx: invalid %s: %v", collection, errs))
+	}
+	return obj["id"].(int)
+}
+
+// Get returns an object as the API renders it, nil when it does not exist.
+func (s *Server) Get(collection string, id int) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[collection][id]
+	if !ok {
+		return nil
+	}
+	return s.render(collection, obj)
+}
+
+// List returns the objects of a collection as the API renders them, in ID
+// order.
+func (s *Server) List(collection string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var objs []map[string]interface{}
+	for _, id := range s.sortedIDs(collection) {
+		objs = append(objs, s.render(collection, s.objects[collection][id]))
+	}
+	return objs
+}
+
+// Update changes fields of an object without any validation, as someone
+// editing it outside of Terraform. It reports whether the object exists.
+func (s *Server) Update(collection string, id int, fields map[string]interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[collection][id]
+	if !ok {
+		return false
+	}
+	for k, v := range normalize(copyValue(fields)).(map[string]interface{}) {
+		obj[k] = v
+	}
+	return true
+}
+
+// Delete deletes an object and what AWX deletes with it. It reports whether
+// the object existed.
+func (s *Server) Delete(collection string, id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.delete(collection, id)
+}
+
+// Associated returns the IDs of the objects listed by a related endpoint of
+// an object, e.g. the credentials of a job template.
+func (s *Server) Associated(collection string, id int, related string) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.links[link{collection, id, related}])
+}
+
+// Associate links two objects through a related endpoint of the first one.
+func (s *Server) Associate(collection string, id int, related string, otherID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.associate(link{collection, id, related}, otherID)
+}
+
+// Disassociate unlinks two objects, as a POST with `disassociate` does.
+func (s *Server) Disassociate(collection string, id int, related string, otherID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.links[link{collection, id, related}], otherID)
+}
+
+// Role returns the ID of a role of an object, e.g. the `admin_role` of an
+// organization, 0 when the object has no such role.
+func (s *Server) Role(collection string, id int, role string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.roles[collection][id][role]
+}
+
+// Setting returns the value of a setting of a category, e.g. `ldap`.
+func (s *Server) Setting(slug, name string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyValue(s.settings[slug][name])
+}
+
+// SetSetting changes the value of a setting of a category.
+func (s *Server) SetSetting(slug, name string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.settings[slug] == nil {
+		s.settings[slug] = make(map[string]interface{})
+	}
+	s.settings[slug][name] = normalize(copyValue(value))
+}
+
+// apiError is an error the API answers with, its body being AWX-like.
+type apiError struct {
+	status int
+	body   interface{}
+}
+
+func notFound() *apiError {
+	return &apiError{http.StatusNotFound, map[string]string{"detail": "Not found."}}
+}
+
+func badRequest(errs map[string][]string) *apiError {
+	return &apiError{http.StatusBadRequest, errs}
+}
+
+func methodNotAllowed(method string) *apiError {
+	return &apiError{http.StatusMethodNotAllowed, map[string]string{"detail": fmt.Sprintf("Method \"%s\" not allowed.", method)}}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, body, apiErr := s.handle(r)
+	if apiErr != nil {
+		status, body = apiErr.status, apiErr.body
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) handle(r *http.Request) (int, interface{}, *apiError) {
+	if r.URL.Path == "/api/" || r.URL.Path == "/api" {
+		return http.StatusOK, map[string]interface{}{
+			"description":        "AWX REST API",
+			"current_version":    APIPath,
+			"available_versions": map[string]string{"v2": APIPath},
+		}, nil
+	}
+	if !strings.HasPrefix(r.URL.Path+"/", APIPath) {
+		return 0, nil, notFound()
+	}
+	// named URLs escape the slashes of the names they are made of
+	var segments []string
+	if p := strings.Trim(strings.TrimPrefix(r.URL.EscapedPath(), APIPath), "/"); p != "" {
+		for _, segment := range strings.Split(p, "/") {
+			segment, _ = url.PathUnescape(segment)
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return http.StatusOK, map[string]string{"ping": APIPath + "ping/", "config": APIPath + "config/"}, nil
+	}
+
+	switch segments[0] {
+	case "ping":
+		return http.StatusOK, map[string]interface{}{
+			"version":      s.version,
+			"ha":           false,
+			"active_node":  "awx",
+			"install_uuid": "00000000-0000-0000-0000-000000000000",
+		}, nil
+	}
+	if r.Header.Get("Authorization") == "" {
+		return 0, nil, &apiError{http.StatusUnauthorized, map[string]string{"detail": "Authentication credentials were not provided."}}
+	}
+
+	var data map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			return 0, nil, &apiError{http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()}}
+		}
+		data = normalize(data).(map[string]interface{})
+	}
+
+	switch segments[0] {
+	case "config":
+		return http.StatusOK, map[string]interface{}{
+			"version":      s.version,
+			"license_info": map[string]interface{}{"license_type": "open", "valid_key": true},
+		}, nil
+	case "me":
+		return s.list(r, "users", func(obj map[string]interface{}) bool { return obj["id"] == 1 })
+	case "settings":
+		return s.handleSettings(r.Method, segments[1:], data)
+	}
+
+	name := segments[0]
+	if _, ok := collections[name]; !ok {
+		return 0, nil, notFound()
+	}
+	switch len(segments) {
+	case 1:
+		switch r.Method {
+		case http.MethodGet:
+			return s.list(r, name, nil)
+		case http.MethodPost:
+			obj, errs := s.create(name, data)
+			if errs != nil {
+				return 0, nil, badRequest(errs)
+			}
+			return http.StatusCreated, s.render(name, obj), nil
+		}
+		return 0, nil, methodNotAllowed(r.Method)
+	case 2:
+		id, ok := s.lookup(name, segments[1])
+		if !ok {
+			return 0, nil, notFound()
+		}
+		return s.handleDetail(r.Method, name, id, data)
+	case 3:
+		id, ok := s.lookup(name, segments[1])
+		if !ok {
+			return 0, nil, notFound()
+		}
+		return s.handleRelated(r, name, id, segments[2], data)
+	}
+	return 0, nil, notFound()
+}
+
+func (s *Server) handleDetail(method, name string, id int, data map[string]interface{}) (int, interface{}, *apiError) {
+	switch method {
+	case http.MethodGet:
+		return http.StatusOK, s.render(name, s.objects[name][id]), nil
+	case http.MethodPut, http.MethodPatch:
+		obj, errs := s.update(name, id, data, method == http.MethodPut)
+		if errs != nil {
+			return 0, nil, badRequest(errs)
+		}
+		return http.StatusOK, s.render(name, obj), nil
+	case http.MethodDelete:
+		s.delete(name, id)
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, methodNotAllowed(method)
+}
+
+func (s *Server) handleRelated(r *http.Request, name string, id int, related string, data map[string]interface{}) (int, interface{}, *apiError) {
+	c := collections[name]
+	switch {
+	case related == "launch" && (name == "job_templates" || name == "workflow_job_templates"):
+		if r.Method == http.MethodGet {
+			return http.StatusOK, map[string]interface{}{"can_start_without_user_input": true}, nil
+		}
+		return s.launch(name, id, data)
+	case related == "survey_spec" && (name == "job_templates" || name == "workflow_job_templates"):
+		return s.handleSurvey(r.Method, name, id, data)
+	case related == "update" && (name == "projects" || name == "inventory_sources"):
+		if r.Method == http.MethodGet {
+			return http.StatusOK, map[string]interface{}{"can_update": true}, nil
+		}
+		jobID := s.startUpdate(name, id)
+		return http.StatusAccepted, map[string]interface{}{"id": jobID, strings.TrimSuffix(collections[name].typ, "_source") + "_update": jobID}, nil
+	case related == "cancel" && isUnifiedJob(name):
+		job := s.objects[name][id]
+		running := job["status"] == "pending" || job["status"] == "waiting" || job["status"] == "running"
+		if r.Method == http.MethodGet {
+			return http.StatusOK, map[string]interface{}{"can_cancel": running}, nil
+		}
+		if !running {
+			return 0, nil, methodNotAllowed(r.Method)
+		}
+		job["status"] = "canceled"
+		return http.StatusAccepted, nil, nil
+	case related == "object_roles" && len(c.roles) > 0:
+		roleIDs := make(map[int]bool)
+		for _, roleID := range s.roles[name][id] {
+			roleIDs[roleID] = true
+		}
+		return s.list(r, "roles", func(obj map[string]interface{}) bool { return roleIDs[obj["id"].(int)] })
+	}
+
+	if target, ok := c.associations[related]; ok {
+		key := link{name, id, related}
+		switch r.Method {
+		case http.MethodGet:
+			linked := s.links[key]
+			return s.list(r, target, func(obj map[string]interface{}) bool { return linked[obj["id"].(int)] })
+		case http.MethodPost:
+			return s.postAssociation(key, target, data)
+		}
+		return 0, nil, methodNotAllowed(r.Method)
+	}
+
+	if ch, ok := c.children[related]; ok {
+		switch r.Method {
+		case http.MethodGet:
+			return s.list(r, ch.collection, func(obj map[string]interface{}) bool { return obj[ch.reference] == id })
+		case http.MethodPost:
+			data[ch.reference] = id
+			obj, errs := s.create(ch.collection, data)
+			if errs != nil {
+				return 0, nil, badRequest(errs)
+			}
+			return http.StatusCreated, s.render(ch.collection, obj), nil
+		}
+		return 0, nil, methodNotAllowed(r.Method)
+	}
+	return 0, nil, notFound()
+}
+
+// postAssociation links, unlinks, or creates then links an object the way
+// AWX related endpoints do.
+func (s *Server) postAssociation(key link, target string, data map[string]interface{}) (int, interface{}, *apiError) {
+	rawID, hasID := data["id"]
+	if !hasID {
+		// the object is created in place, a workflow node being created in
+		// the workflow of its parent
+		if key.collection == "workflow_job_template_nodes" {
+			data["workflow_job_template"] = s.objects[key.collection][key.id]["workflow_job_template"]
+		}
+		obj, errs := s.create(target, data)
+		if errs != nil {
+			return 0, nil, badRequest(errs)
+		}
+		s.associate(key, obj["id"].(int))
+		return http.StatusCreated, s.render(target, obj), nil
+	}
+
+	otherID, ok := rawID.(int)
+	if !ok {
+		return 0, nil, badRequest(map[string][]string{"msg": {"\"id\" field must be an integer."}})
+	}
+	if _, exists := s.objects[target][otherID]; !exists {
+		return 0, nil, badRequest(map[string][]string{"msg": {fmt.Sprintf("Object with id %d not found.", otherID)}})
+	}
+	if disassociate, _ := data["disassociate"].(bool); disassociate {
+		delete(s.links[key], otherID)
+		return http.StatusNoContent, nil, nil
+	}
+	if key.collection == target && key.id == otherID {
+		return 0, nil, badRequest(map[string][]string{"Error": {"Cycle detected."}})
+	}
+	s.associate(key, otherID)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) associate(key link, otherID int) {
+	if s.links[key] == nil {
+		s.links[key] = make(map[int]bool)
+	}
+	s.links[key][otherID] = true
+}
+
+// launch starts a job of a job template, or a workflow job. Jobs succeed as
+// soon as they are launched.
+func (s *Server) launch(name string, id int, data map[string]interface{}) (int, interface{}, *apiError) {
+	template := s.objects[name][id]
+	jobs, reference := "jobs", "job_template"
+	if name == "workflow_job_templates" {
+		jobs, reference = "workflow_jobs", "workflow_job_template"
+	}
+
+	job := map[string]interface{}{
+		reference:    id,
+		"name":       template["name"],
+		"status":     "successful",
+		"failed":     false,
+		"extra_vars": template["extra_vars"],
+		"limit":      template["limit"],
+		"inventory":  template["inventory"],
+	}
+	ignored := make(map[string]interface{})
+	for field, ask := range map[string]string{
+		"extra_vars": "ask_variables_on_launch",
+		"limit":      "ask_limit_on_launch",
+		"inventory":  "ask_inventory_on_launch",
+	} {
+		value, ok := data[field]
+		if !ok || value == nil || value == "" {
+			continue
+		}
+		if allowed, _ := template[ask].(bool); !allowed && field != "extra_vars" {
+			ignored[field] = value
+			continue
+		}
+		if field == "extra_vars" {
+			if vars, isMap := value.(map[string]interface{}); isMap {
+				encoded, _ := json.Marshal(vars)
+				value = string(encoded)
+			}
+		}
+		job[field] = value
+	}
+	if job["inventory"] == nil && name == "job_templates" {
+		return 0, nil, badRequest(map[string][]string{"inventory": {"Job Template 'inventory' is missing or undefined."}})
+	}
+
+	jobID := s.insert(jobs, job)
+	body := s.render(jobs, s.objects[jobs][jobID])
+	body[strings.TrimSuffix(collections[jobs].typ, "s")] = jobID
+	body["ignored_fields"] = ignored
+	return http.StatusCreated, body, nil
+}
+
+// startUpdate runs a successful update of a project or inventory source.
+func (s *Server) startUpdate(name string, id int) int {
+	jobs, reference := "project_updates", "project"
+	if name == "inventory_sources" {
+		jobs, reference = "inventory_updates", "inventory_source"
+	}
+	jobID := s.insert(jobs, map[string]interface{}{
+		reference: id,
+		"name":    s.objects[name][id]["name"],
+		"status":  "successful",
+		"failed":  false,
+	})
+	s.objects[name][id]["_last_job"] = jobID
+	return jobID
+}
+
+func (s *Server) handleSettings(method string, segments []string, data map[string]interface{}) (int, interface{}, *apiError) {
+	if len(segments) != 1 {
+		if len(segments) == 0 && method == http.MethodGet {
+			var slugs []string
+			for slug := range s.settings {
+				slugs = append(slugs, slug)
+			}
+			sort.Strings(slugs)
+			var results []interface{}
+			for _, slug := range slugs {
+				results = append(results, map[string]string{"slug": slug, "url": APIPath + "settings/" + slug + "/"})
+			}
+			return http.StatusOK, map[string]interface{}{"count": len(results), "results": results}, nil
+		}
+		return 0, nil, notFound()
+	}
+
+	slug := segments[0]
+	if _, ok := s.settings[slug]; !ok && slug != "all" {
+		return 0, nil, notFound()
+	}
+	switch method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPatch:
+		for name, value := range data {
+			target := slug
+			if slug == "all" {
+				target = s.settingSlug(name)
+			}
+			s.settings[target][name] = value
+		}
+	case http.MethodDelete:
+		defaults := defaultSettings()
+		for target := range s.settings {
+			if slug == "all" || slug == target {
+				s.settings[target] = defaults[target]
+			}
+		}
+		return http.StatusNoContent, nil, nil
+	default:
+		return 0, nil, methodNotAllowed(method)
+	}
+
+	values := make(map[string]interface{})
+	for target, settings := range s.settings {
+		if slug != "all" && slug != target {
+			continue
+		}
+		for name, value := range settings {
+			values[name] = copyValue(value)
+		}
+	}
+	return http.StatusOK, values, nil
+}
+
+// settingSlug returns the category of a setting, new settings going to `system`.
+func (s *Server) settingSlug(name string) string {
+	for slug, settings := range s.settings {
+		if _, ok := settings[name]; ok {
+			return slug
+		}
+	}
+	return "system"
+}
+
+func defaultSettings() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"system": {
+			"ACTIVITY_STREAM_ENABLED":      true,
+			"ORG_ADMINS_CAN_SEE_ALL_USERS": true,
+			"TOWER_URL_BASE":               "https://towerhost",
+			"INSIGHTS_TRACKING_STATE":      false,
+		},
+		"jobs": {
+			"AD_HOC_COMMANDS":          []interface{}{"command", "shell"},
+			"AWX_ISOLATION_SHOW_PATHS": []interface{}{},
+			"DEFAULT_JOB_TIMEOUT":      0,
+			"AWX_TASK_ENV":             map[string]interface{}{},
+		},
+		"ldap": {
+			"AUTH_LDAP_SERVER_URI":       "",
+			"AUTH_LDAP_ORGANIZATION_MAP": map[string]interface{}{},
+			"AUTH_LDAP_TEAM_MAP":         map[string]interface{}{},
+		},
+	}
+}
+
+// sequence returns the name of the ID sequence of a collection.
+func sequence(name string) string {
+	if contains(unifiedJobTemplates, name) {
+		return unified
+	}
+	if isUnifiedJob(name) {
+		return "unified_jobs"
+	}
+	return name
+}
+
+func isUnifiedJob(name string) bool {
+	return contains(unifiedJobs, name)
+}
+
+// insert stores an object without validation and returns its ID, an id
+// among fields being kept.
+func (s *Server) insert(name string, fields map[string]interface{}) int {
+	seq := sequence(name)
+	id, ok := fields["id"].(int)
+	if !ok {
+		id = s.lastIDs[seq] + 1
+	}
+	if id > s.lastIDs[seq] {
+		s.lastIDs[seq] = id
+	}
+
+	c := collections[name]
+	obj := make(map[string]interface{})
+	for k, v := range c.defaults {
+		obj[k] = copyValue(v)
+	}
+	for reference := range c.references {
+		obj[reference] = nil
+	}
+	for k, v := range fields {
+		obj[k] = v
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	obj["id"] = id
+	obj["created"] = now
+	obj["modified"] = now
+
+	if s.objects[name] == nil {
+		s.objects[name] = make(map[int]map[string]interface{})
+	}
+	s.objects[name][id] = obj
+
+	for _, role := range c.roles {
+		roleID := s.insert("roles", map[string]interface{}{
+			"name":        roleNames[role],
+			"description": fmt.Sprintf("Can %s the %s", strings.ToLower(roleNames[role]), strings.ReplaceAll(c.typ, "_", " ")),
+			"_resource":   link{name, id, role},
+		})
+		if s.roles[name] == nil {
+			s.roles[name] = make(map[int]map[string]int)
+		}
+		if s.roles[name][id] == nil {
+			s.roles[name][id] = make(map[string]int)
+		}
+		s.roles[name][id][role] = roleID
+	}
+	return id
+}
+
+// create validates and stores a new object, returning the errors AWX
+// would answer with.
+func (s *Server) create(name string, data map[string]interface{}) (map[string]interface{}, map[string][]string) {
+	c := collections[name]
+	fields := s.allowedFields(name, data)
+	errs := make(map[string][]string)
+	for _, field := range c.required {
+		if _, ok := fields[field]; !ok {
+			errs[field] = append(errs[field], "This field is required.")
+		}
+	}
+	s.validate(name, 0, fields, errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	switch name {
+	case "job_templates":
+		// the organization of a job template is the one of its project
+		if project, ok := s.objects["projects"][intValue(fields["project"])]; ok {
+			fields["organization"] = project["organization"]
+		}
+	case "workflow_job_template_nodes":
+		if identifier, _ := fields["identifier"].(string); identifier == "" {
+			fields["identifier"] = newUUID()
+		}
+	}
+
+	id := s.insert(name, fields)
+	if name == "projects" && fields["scm_type"] != nil && fields["scm_type"] != "" {
+		s.startUpdate(name, id)
+	}
+	return s.objects[name][id], nil
+}
+
+// update validates and stores changes to an object. A PUT must give all the
+// required fields.
+func (s *Server) update(name string, id int, data map[string]interface{}, put bool) (map[string]interface{}, map[string][]string) {
+	c := collections[name]
+	fields := s.allowedFields(name, data)
+	errs := make(map[string][]string)
+	if put {
+		for _, field := range c.required {
+			if _, ok := fields[field]; !ok {
+				errs[field] = append(errs[field], "This field is required.")
+			}
+		}
+	}
+
+	if inputs, ok := fields["inputs"].(map[string]interface{}); ok && name == "credentials" {
+		previous, _ := s.objects[name][id]["inputs"].(map[string]interface{})
+		for _, field := range s.secretInputs(s.objects[name][id]) {
+			if inputs[field] == encrypted {
+				inputs[field] = previous[field]
+			}
+		}
+	}
+
+	merged := make(map[string]interface{})
+	for k, v := range s.objects[name][id] {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	s.validate(name, id, fields, errs)
+	if len(errs) == 0 {
+		s.validateUnique(name, id, merged, errs)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	obj := s.objects[name][id]
+	for k, v := range fields {
+		obj[k] = v
+	}
+	obj["modified"] = time.Now().UTC().Format(time.RFC3339Nano)
+	return obj, nil
+}
+
+// allowedFields drops the fields AWX ignores, the read only ones and those
+// unknown to the collection.
+func (s *Server) allowedFields(name string, data map[string]interface{}) map[string]interface{} {
+	c := collections[name]
+	fields := make(map[string]interface{})
+	for k, v := range data {
+		_, isDefault := c.defaults[k]
+		_, isReference := c.references[k]
+		if isDefault || isReference || contains(c.required, k) || contains(c.unique, k) || contains(c.namedURL, k) || name == "settings" {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// validate checks the given fields, normalizing references to IDs or null.
+// Uniqueness is checked on creation here, and by update on the merged object.
+func (s *Server) validate(name string, id int, fields map[string]interface{}, errs map[string][]string) {
+	c := collections[name]
+	for _, field := range c.required {
+		value, ok := fields[field]
+		switch {
+		case !ok:
+		case value == nil:
+			errs[field] = append(errs[field], "This field may not be null.")
+		case value == "":
+			errs[field] = append(errs[field], "This field may not be blank.")
+		}
+	}
+
+	for field, target := range c.references {
+		value, ok := fields[field]
+		if !ok || len(errs[field]) > 0 {
+			continue
+		}
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v == "" {
+				fields[field] = nil
+				continue
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs[field] = append(errs[field], fmt.Sprintf("Incorrect type. Expected pk value, received %s.", "str"))
+				continue
+			}
+			fields[field] = n
+		case int:
+		default:
+			errs[field] = append(errs[field], fmt.Sprintf("Incorrect type. Expected pk value, received %T.", v))
+			continue
+		}
+		if ref, isInt := fields[field].(int); isInt && !s.exists(target, ref) {
+			errs[field] = append(errs[field], fmt.Sprintf("Invalid pk \"%d\" - object does not exist.", ref))
+		}
+	}
+
+	for field, choices := range c.choices {
+		value, ok := fields[field]
+		if !ok || value == nil {
+			continue
+		}
+		valid := false
+		for _, choice := range choices {
+			if fmt.Sprint(choice) == fmt.Sprint(value) {
+				valid = true
+			}
+		}
+		if !valid {
+			errs[field] = append(errs[field], fmt.Sprintf("\"%v\" is not a valid choice.", value))
+		}
+	}
+
+	if id == 0 && len(errs) == 0 {
+		obj := make(map[string]interface{})
+		for reference := range c.references {
+			obj[reference] = nil
+		}
+		for k, v := range fields {
+			obj[k] = v
+		}
+		s.validateUnique(name, 0, obj, errs)
+	}
+}
+
+func (s *Server) validateUnique(name string, id int, obj map[string]interface{}, errs map[string][]string) {
+	c := collections[name]
+	if len(c.unique) == 0 {
+		return
+	}
+	for otherID, other := range s.objects[name] {
+		if otherID == id {
+			continue
+		}
+		same := true
+		for _, field := range c.unique {
+			if fmt.Sprint(other[field]) != fmt.Sprint(obj[field]) {
+				same = false
+			}
+		}
+		if !same {
+			continue
+		}
+		typeName := title(c.typ)
+		if len(c.unique) == 1 {
+			errs[c.unique[0]] = append(errs[c.unique[0]], fmt.Sprintf("%s with this %s already exists.", typeName, title(c.unique[0])))
+			return
+		}
+		var names []string
+		for _, field := range c.unique {
+			names = append(names, title(field))
+		}
+		errs["__all__"] = append(errs["__all__"], fmt.Sprintf("%s with this %s already exists.", typeName, strings.Join(names, " and ")))
+		return
+	}
+}
+
+func (s *Server) exists(name string, id int) bool {
+	if name == unified {
+		for _, n := range unifiedJobTemplates {
+			if _, ok := s.objects[n][id]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	_, ok := s.objects[name][id]
+	return ok
+}
+
+// resolve returns the collection and object a reference to name points to.
+func (s *Server) resolve(name string, id int) (string, map[string]interface{}) {
+	if name == unified {
+		for _, n := range unifiedJobTemplates {
+			if obj, ok := s.objects[n][id]; ok {
+				return n, obj
+			}
+		}
+		return "", nil
+	}
+	return name, s.objects[name][id]
+}
+
+// delete removes an object, the objects it owns, its roles and links, and
+// nullifies the other references to it.
+func (s *Server) delete(name string, id int) bool {
+	if _, ok := s.objects[name][id]; !ok {
+		return false
+	}
+	delete(s.objects[name], id)
+	delete(s.surveys[name], id)
+	for _, roleID := range s.roles[name][id] {
+		s.delete("roles", roleID)
+	}
+	delete(s.roles[name], id)
+
+	for key, ids := range s.links {
+		if key.collection == name && key.id == id {
+			delete(s.links, key)
+			continue
+		}
+		if target := collections[key.collection].associations[key.related]; target == name {
+			delete(ids, id)
+		}
+	}
+
+	for other, c := range collections {
+		for reference, target := range c.references {
+			if target != name && !(target == unified && contains(unifiedJobTemplates, name)) {
+				continue
+			}
+			for _, otherID := range sortedIDsOf(s.objects[other]) {
+				obj, ok := s.objects[other][otherID]
+				if !ok || obj[reference] != id {
+					continue
+				}
+				if contains(c.owners, reference) {
+					s.delete(other, otherID)
+				} else {
+					obj[reference] = nil
+				}
+			}
+		}
+	}
+	return true
+}
+
+// lookup returns the ID of an object from its ID or named URL.
+func (s *Server) lookup(name, idOrNamedURL string) (int, bool) {
+	if id, err := strconv.Atoi(idOrNamedURL); err == nil {
+		_, ok := s.objects[name][id]
+		return id, ok
+	}
+	if len(collections[name].namedURL) == 0 {
+		return 0, false
+	}
+	for _, id := range s.sortedIDs(name) {
+		if s.namedURL(name, s.objects[name][id]) == idOrNamedURL {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// namedURL builds the AWX named URL of an object, e.g.
+// `Deploy++Engineering` for a job template of the Engineering organization.
+func (s *Server) namedURL(name string, obj map[string]interface{}) string {
+	c := collections[name]
+	var parts []string
+	for _, field := range c.namedURL {
+		parts = append(parts, fmt.Sprint(valueOrEmpty(obj[field])))
+	}
+	namedURL := strings.Join(parts, "+")
+	for _, parent := range c.parents {
+		parentName, parentObj := s.resolve(c.references[parent], intValue(obj[parent]))
+		if parentObj == nil {
+			namedURL += "++"
+			continue
+		}
+		namedURL += "++" + s.namedURL(parentName, parentObj)
+	}
+	return namedURL
+}
+
+// render returns an object the way the API does, with its type, URLs and
+// summary fields.
+func (s *Server) render(name string, obj map[string]interface{}) map[string]interface{} {
+	c := collections[name]
+	id := obj["id"].(int)
+	url := fmt.Sprintf("%s%s/%d/", APIPath, name, id)
+
+	rendered := make(map[string]interface{})
+	for k, v := range obj {
+		if !strings.HasPrefix(k, "_") {
+			rendered[k] = copyValue(v)
+		}
+	}
+	rendered["type"] = c.typ
+	rendered["url"] = url
+
+	related := make(map[string]string)
+	for r := range c.associations {
+		related[r] = url + r + "/"
+	}
+	for r := range c.children {
+		related[r] = url + r + "/"
+	}
+	summary := make(map[string]interface{})
+	for reference, target := range c.references {
+		refName, ref := s.resolve(target, intValue(obj[reference]))
+		if ref == nil {
+			continue
+		}
+		related[reference] = fmt.Sprintf("%s%s/%d/", APIPath, refName, ref["id"])
+		if reference == "organization" || reference == "credential_type" || reference == "execution_environment" {
+			summary[reference] = map[string]interface{}{
+				"id":          ref["id"],
+				"name":        ref["name"],
+				"description": ref["description"],
+			}
+		}
+	}
+	rendered["related"] = related
+
+	if len(c.roles) > 0 {
+		objectRoles := make(map[string]interface{})
+		for role, roleID := range s.roles[name][id] {
+			r := s.objects["roles"][roleID]
+			objectRoles[role] = map[string]interface{}{
+				"id":          roleID,
+				"name":        r["name"],
+				"description": r["description"],
+			}
+		}
+		summary["object_roles"] = objectRoles
+	}
+	summary["user_capabilities"] = map[string]bool{"edit": true, "delete": true}
+
+	switch name {
+	case "roles":
+		resource := obj["_resource"].(link)
+		if res, ok := s.objects[resource.collection][resource.id]; ok {
+			summary["resource_name"] = res["name"]
+			summary["resource_type"] = collections[resource.collection].typ
+			summary["resource_type_display_name"] = title(collections[resource.collection].typ)
+			summary["resource_id"] = resource.id
+		}
+	case "projects", "inventory_sources":
+		if jobID, ok := obj["_last_job"].(int); ok {
+			jobs := "project_updates"
+			if name == "inventory_sources" {
+				jobs = "inventory_updates"
+			}
+			if job, exists := s.objects[jobs][jobID]; exists {
+				summary["last_job"] = map[string]interface{}{"id": jobID, "status": job["status"], "failed": job["failed"]}
+				summary["last_update"] = summary["last_job"]
+				if job["status"] == "pending" || job["status"] == "waiting" || job["status"] == "running" {
+					summary["current_job"] = summary["last_job"]
+				}
+			}
+		}
+	case "credentials":
+		if credentialType, ok := s.objects["credential_types"][intValue(obj["credential_type"])]; ok {
+			rendered["kind"] = credentialType["namespace"]
+			rendered["cloud"] = credentialType["kind"] == "cloud"
+			if inputs, ok := rendered["inputs"].(map[string]interface{}); ok {
+				for _, field := range s.secretInputs(obj) {
+					if valueOrEmpty(inputs[field]) != "" {
+						inputs[field] = encrypted
+					}
+				}
+			}
+		}
+	case "users":
+		delete(rendered, "password")
+	case "workflow_job_template_nodes":
+		for _, r := range []string{"success_nodes", "failure_nodes", "always_nodes"} {
+			rendered[r] = sortedKeys(s.links[link{name, id, r}])
+		}
+	}
+	rendered["summary_fields"] = summary
+	return rendered
+}
+
+// secretInputs lists the input fields the type of a credential declares as
+// secret.
+func (s *Server) secretInputs(credential map[string]interface{}) []string {
+	var secrets []string
+	credentialType := s.objects["credential_types"][intValue(credential["credential_type"])]
+	inputs, _ := credentialType["inputs"].(map[string]interface{})
+	fields, _ := inputs["fields"].([]interface{})
+	for _, field := range fields {
+		if field, ok := field.(map[string]interface{}); ok && field["secret"] == true {
+			if id, ok := field["id"].(string); ok {
+				secrets = append(secrets, id)
+			}
+		}
+	}
+	return secrets
+}
+
+// list answers a GET on a list endpoint, filtering, ordering and paginating
+// the objects the way AWX does. include, when set, restricts the objects
+// listed, e.g. to those associated to another one.
+func (s *Server) list(r *http.Request, name string, include func(map[string]interface{}) bool) (int, interface{}, *apiError) {
+	query := r.URL.Query()
+	var objs []map[string]interface{}
+	for _, id := range s.sortedIDs(name) {
+		obj := s.render(name, s.objects[name][id])
+		if include != nil && !include(obj) {
+			continue
+		}
+		match, err := matchFilters(obj, query)
+		if err != nil {
+			return 0, nil, err
+		}
+		if match {
+			objs = append(objs, obj)
+		}
+	}
+
+	if orderBy := query.Get("order_by"); orderBy != "" {
+		field := strings.TrimPrefix(orderBy, "-")
+		sort.SliceStable(objs, func(i, j int) bool {
+			less := compareValues(objs[i][field], objs[j][field]) < 0
+			if strings.HasPrefix(orderBy, "-") {
+				return compareValues(objs[i][field], objs[j][field]) > 0
+			}
+			return less
+		})
+	}
+
+	pageSize := s.pageSize
+	if v := query.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, nil, &apiError{http.StatusBadRequest, map[string]string{"detail": "Invalid page size."}}
+		}
+		pageSize = n
+		if pageSize > 200 {
+			pageSize = 200
+		}
+	}
+	page := 1
+	if v := query.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || (n-1)*pageSize >= len(objs) && n > 1 {
+			return 0, nil, &apiError{http.StatusNotFound, map[string]string{"detail": "Invalid page."}}
+		}
+		page = n
+	}
+
+	start := (page - 1) * pageSize
+	end := start + pageSize
+	if end > len(objs) {
+		end = len(objs)
+	}
+	results := make([]interface{}, 0, end-start)
+	for _, obj := range objs[start:end] {
+		results = append(results, obj)
+	}
+
+	pageURL := func(n int) interface{} {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(n))
+		return r.URL.Path + "?" + q.Encode()
+	}
+	var next, previous interface{}
+	if end < len(objs) {
+		next = pageURL(page + 1)
+	}
+	if page > 1 {
+		previous = pageURL(page - 1)
+	}
+	return http.StatusOK, map[string]interface{}{
+		"count":    len(objs),
+		"next":     next,
+		"previous": previous,
+		"results":  results,
+	}, nil
+}
+
+// matchFilters reports whether an object matches the field lookups of a
+// query, e.g. `name=Default` or `organization__isnull=true`.
+func matchFilters(obj map[string]interface{}, query map[string][]string) (bool, *apiError) {
+	for key, values := range query {
+		switch key {
+		case "page", "page_size", "order_by", "search":
+			continue
+		}
+		field, lookup := key, "exact"
+		if i := strings.Index(key, "__"); i >= 0 {
+			field, lookup = key[:i], key[i+2:]
+		}
+		value, known := obj[field]
+		if !known {
+			return false, &apiError{http.StatusBadRequest, map[string]string{"detail": fmt.Sprintf("Cannot resolve keyword '%s' into field.", field)}}
+		}
+		for _, want := range values {
+			ok, err := matchLookup(value, lookup, want)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func matchLookup(value interface{}, lookup, want string) (bool, *apiError) {
+	got := fmt.Sprint(valueOrEmpty(value))
+	if b, ok := value.(bool); ok {
+		if w, err := strconv.ParseBool(want); err == nil {
+			return b == w, nil
+		}
+	}
+	switch lookup {
+	case "exact":
+		return value != nil && got == want, nil
+	case "iexact":
+		return value != nil && strings.EqualFold(got, want), nil
+	case "contains":
+		return strings.Contains(got, want), nil
+	case "icontains":
+		return strings.Contains(strings.ToLower(got), strings.ToLower(want)), nil
+	case "startswith":
+		return strings.HasPrefix(got, want), nil
+	case "in":
+		for _, w := range strings.Split(want, ",") {
+			if value != nil && got == w {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "isnull":
+		isNull, err := strconv.ParseBool(want)
+		if err != nil {
+			return false, &apiError{http.StatusBadRequest, map[string]string{"detail": fmt.Sprintf("Invalid boolean value %q.", want)}}
+		}
+		return (value == nil) == isNull, nil
+	}
+	return false, &apiError{http.StatusBadRequest, map[string]string{"detail": fmt.Sprintf("Unsupported lookup '%s'.", lookup)}}
+}
+
+func compareValues(a, b interface{}) int {
+	ai, aIsInt := a.(int)
+	bi, bIsInt := b.(int)
+	if aIsInt && bIsInt {
+		return ai - bi
+	}
+	return strings.Compare(fmt.Sprint(valueOrEmpty(a)), fmt.Sprint(valueOrEmpty(b)))
+}
+
+func (s *Server) sortedIDs(name string) []int {
+	return sortedIDsOf(s.objects[name])
+}
+
+func sortedIDsOf(objs map[int]map[string]interface{}) []int {
+	ids := make([]int, 0, len(objs))
+	for id := range objs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func sortedKeys(set map[int]bool) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// title turns a field name into the words AWX messages use, e.g.
+// `Job Template` for job_template.
+func title(field string) string {
+	words := strings.Split(field, "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func intValue(v interface{}) int {
+	n, _ := v.(int)
+	return n
+}
+
+func valueOrEmpty(v interface{}) interface{} {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+// normalize turns the integral JSON numbers into ints, as IDs are compared
+// with them.
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case float64:
+		if t == float64(int(t)) {
+			return int(t)
+		}
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = normalize(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = normalize(e)
+		}
+	}
+	return v
+}
+
+// copyValue deep copies a JSON-like value.
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			c[k] = copyValue(e)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(t))
+		for i, e := range t {
+			c[i] = copyValue(e)
+		}
+		return c
+	}
+	return v
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,198 @@
+package fakeawx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// request sends a request to the server and decodes its JSON response.
+func request(t *testing.T, s *Server, method, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	var payload io.Reader
+	if body != nil {
+		encoded, _ := json.Marshal(body)
+		payload = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, s.URL+path, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer test")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestUnauthenticated(t *testing.T) {
+	s := New()
+	defer s.Close()
+	resp, err := s.Client().Get(s.URL + APIPath + "organizations/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status is %d, expected %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	s := New()
+	defer s.Close()
+	s.SetPageSize(2)
+	for i := 0; i < 5; i++ {
+		s.Add("organizations", map[string]interface{}{"name": fmt.Sprintf("org%d", i)})
+	}
+
+	var names []string
+	path := APIPath + "organizations/?order_by=name"
+	for path != "" {
+		status, page := request(t, s, http.MethodGet, path, nil)
+		if status != http.StatusOK {
+			t.Fatalf("status of %s is %d", path, status)
+		}
+		if page["count"] != float64(5) {
+			t.Errorf("count is %v, expected 5", page["count"])
+		}
+		for _, result := range page["results"].([]interface{}) {
+			names = append(names, result.(map[string]interface{})["name"].(string))
+		}
+		path, _ = page["next"].(string)
+	}
+	if strings.Join(names, ",") != "org0,org1,org2,org3,org4" {
+		t.Errorf("organizations are %v", names)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	s := New()
+	defer s.Close()
+	orgID := s.Add("organizations", map[string]interface{}{"name": "org"})
+
+	for _, tc := range []struct {
+		name     string
+		body     map[string]interface{}
+		field    string
+		expected string
+	}{
+		{"required", map[string]interface{}{"organization": orgID}, "name", "This field is required."},
+		{"reference", map[string]interface{}{"name": "inv", "organization": 42}, "organization", `Invalid pk "42" - object does not exist.`},
+		{"choice", map[string]interface{}{"name": "inv", "organization": orgID, "kind": "bogus"}, "kind", `"bogus" is not a valid choice.`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := request(t, s, http.MethodPost, APIPath+"inventories/", tc.body)
+			if status != http.StatusBadRequest {
+				t.Fatalf("status is %d, expected %d", status, http.StatusBadRequest)
+			}
+			if fmt.Sprint(body[tc.field]) != fmt.Sprint([]string{tc.expected}) {
+				t.Errorf("errors are %v, expected %s: [%s]", body, tc.field, tc.expected)
+			}
+		})
+	}
+
+	s.Add("inventories", map[string]interface{}{"name": "inv", "organization": orgID})
+	status, body := request(t, s, http.MethodPost, APIPath+"inventories/", map[string]interface{}{"name": "inv", "organization": orgID})
+	if status != http.StatusBadRequest || body["__all__"] == nil {
+		t.Errorf("duplicate inventory got %d %v, expected a __all__ error", status, body)
+	}
+}
+
+func TestNamedURL(t *testing.T) {
+	s := New()
+	defer s.Close()
+	orgID := s.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := s.Add("inventories", map[string]interface{}{"name": "inv", "organization": orgID})
+	hostID := s.Add("hosts", map[string]interface{}{"name": "web", "inventory": inventoryID})
+
+	status, body := request(t, s, http.MethodGet, APIPath+"hosts/web++inv++org/", nil)
+	if status != http.StatusOK || body["id"] != float64(hostID) {
+		t.Errorf("host named URL got %d %v, expected host %d", status, body, hostID)
+	}
+	status, _ = request(t, s, http.MethodGet, APIPath+"hosts/web++inv++other/", nil)
+	if status != http.StatusNotFound {
+		t.Errorf("unknown named URL got %d, expected %d", status, http.StatusNotFound)
+	}
+}
+
+func TestDeleteCascades(t *testing.T) {
+	s := New()
+	defer s.Close()
+	orgID := s.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := s.Add("inventories", map[string]interface{}{"name": "inv", "organization": orgID})
+	hostID := s.Add("hosts", map[string]interface{}{"name": "web", "inventory": inventoryID})
+
+	status, _ := request(t, s, http.MethodDelete, fmt.Sprintf("%sinventories/%d/", APIPath, inventoryID), nil)
+	if status != http.StatusNoContent {
+		t.Fatalf("status is %d, expected %d", status, http.StatusNoContent)
+	}
+	if s.Get("hosts", hostID) != nil {
+		t.Errorf("host %d outlived its inventory", hostID)
+	}
+}
+
+func TestSurvey(t *testing.T) {
+	s := New()
+	defer s.Close()
+	orgID := s.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := s.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	id := s.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml"})
+	path := fmt.Sprintf("%sjob_templates/%d/survey_spec/", APIPath, id)
+
+	if status, body := request(t, s, http.MethodGet, path, nil); status != http.StatusOK || len(body) != 0 {
+		t.Fatalf("survey of a new job template is %d %v", status, body)
+	}
+	if status, body := request(t, s, http.MethodPost, path, map[string]interface{}{"name": "", "description": "", "spec": []interface{}{}}); status != http.StatusBadRequest || body["error"] == nil {
+		t.Errorf("empty spec answered %d %v", status, body)
+	}
+
+	question := map[string]interface{}{"type": "password", "question_name": "Token", "variable": "token", "required": true, "default": "secret"}
+	if status, body := request(t, s, http.MethodPost, path, map[string]interface{}{"name": "", "description": "", "spec": []interface{}{question}}); status != http.StatusOK {
+		t.Fatalf("spec answered %d %v", status, body)
+	}
+	_, body := request(t, s, http.MethodGet, path, nil)
+	if got := body["spec"].([]interface{})[0].(map[string]interface{})["default"]; got != encrypted {
+		t.Errorf("password default answered as %v", got)
+	}
+
+	question["default"] = encrypted
+	request(t, s, http.MethodPost, path, map[string]interface{}{"name": "", "description": "", "spec": []interface{}{question}})
+	if got := s.Survey("job_templates", id)["spec"].([]interface{})[0].(map[string]interface{})["default"]; got != "secret" {
+		t.Errorf("password default posted as %s changed to %v", encrypted, got)
+	}
+
+	request(t, s, http.MethodDelete, path, nil)
+	if spec := s.Survey("job_templates", id); spec != nil {
+		t.Errorf("deleted survey is %v", spec)
+	}
+}
+
+func TestCredentialSecrets(t *testing.T) {
+	s := New()
+	defer s.Close()
+	id := s.Add("credentials", map[string]interface{}{"name": "ssh", "credential_type": 1, "inputs": map[string]interface{}{"username": "root", "password": "secret"}})
+	path := fmt.Sprintf("%scredentials/%d/", APIPath, id)
+
+	_, body := request(t, s, http.MethodGet, path, nil)
+	inputs := body["inputs"].(map[string]interface{})
+	if inputs["password"] != encrypted || inputs["username"] != "root" {
+		t.Errorf("inputs answered as %v", inputs)
+	}
+
+	if status, body := request(t, s, http.MethodPatch, path, map[string]interface{}{"inputs": map[string]interface{}{"username": "admin", "password": encrypted}}); status != http.StatusOK {
+		t.Fatalf("update answered %d %v", status, body)
+	}
+	if got := s.objects["credentials"][id]["inputs"].(map[string]interface{}); got["password"] != "secret" || got["username"] != "admin" {
+		t.Errorf("inputs posted with the password as %s stored as %v", encrypted, got)
+	}
+}
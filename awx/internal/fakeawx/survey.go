@@ -0,0 +1,119 @@
+package fakeawx
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// encrypted is what AWX answers in place of the secrets it stores.
+const encrypted = "$encrypted$"
+
+// surveyTypes are the question types AWX accepts in a survey spec.
+var surveyTypes = []string{"text", "textarea", "password", "integer", "float", "multiplechoice", "multiselect"}
+
+// Survey returns the survey spec of a job template or workflow job template
+// as it is stored, password defaults included, nil when it has none.
+func (s *Server) Survey(collection string, id int) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if spec := s.surveys[collection][id]; spec != nil {
+		return copyValue(spec).(map[string]interface{})
+	}
+	return nil
+}
+
+// SetSurvey replaces the survey spec of a job template or workflow job
+// template.
+func (s *Server) SetSurvey(collection string, id int, spec map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.surveys[collection] == nil {
+		s.surveys[collection] = make(map[int]map[string]interface{})
+	}
+	s.surveys[collection][id] = normalize(copyValue(spec)).(map[string]interface{})
+}
+
+// handleSurvey serves the `survey_spec` endpoint of a job template or
+// workflow job template. Like AWX, the defaults of the password questions
+// are answered as `$encrypted$`, and keep their value when posted so.
+func (s *Server) handleSurvey(method, name string, id int, data map[string]interface{}) (int, interface{}, *apiError) {
+	switch method {
+	case http.MethodGet:
+		spec := s.surveys[name][id]
+		if spec == nil {
+			return http.StatusOK, map[string]interface{}{}, nil
+		}
+		rendered := copyValue(spec).(map[string]interface{})
+		for _, question := range rendered["spec"].([]interface{}) {
+			question := question.(map[string]interface{})
+			if question["type"] == "password" && valueOrEmpty(question["default"]) != "" {
+				question["default"] = encrypted
+			}
+		}
+		return http.StatusOK, rendered, nil
+	case http.MethodPost:
+		if msg := validateSurvey(data); msg != "" {
+			return 0, nil, &apiError{http.StatusBadRequest, map[string]string{"error": msg}}
+		}
+		previous := make(map[string]interface{})
+		if spec := s.surveys[name][id]; spec != nil {
+			for _, question := range spec["spec"].([]interface{}) {
+				question := question.(map[string]interface{})
+				previous[question["variable"].(string)] = question["default"]
+			}
+		}
+		for _, question := range data["spec"].([]interface{}) {
+			question := question.(map[string]interface{})
+			if question["type"] == "password" && question["default"] == encrypted {
+				question["default"] = previous[question["variable"].(string)]
+			}
+		}
+		if s.surveys[name] == nil {
+			s.surveys[name] = make(map[int]map[string]interface{})
+		}
+		s.surveys[name][id] = data
+		return http.StatusOK, nil, nil
+	case http.MethodDelete:
+		delete(s.surveys[name], id)
+		return http.StatusOK, nil, nil
+	}
+	return 0, nil, methodNotAllowed(method)
+}
+
+// validateSurvey returns the error AWX answers a spec with, empty when the
+// spec is valid.
+func validateSurvey(data map[string]interface{}) string {
+	for _, key := range []string{"name", "description"} {
+		if _, ok := data[key].(string); !ok {
+			return fmt.Sprintf("'%s' missing from survey spec.", key)
+		}
+	}
+	questions, ok := data["spec"].([]interface{})
+	if !ok {
+		return "'spec' must be a list of items."
+	}
+	if len(questions) == 0 {
+		return "'spec' doesn't contain any items."
+	}
+	variables := make(map[string]bool)
+	for i, item := range questions {
+		question, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Sprintf("Survey question %d is not a json object.", i)
+		}
+		for _, key := range []string{"type", "question_name", "variable", "required"} {
+			if _, ok := question[key]; !ok {
+				return fmt.Sprintf("'%s' missing from survey question %d.", key, i)
+			}
+		}
+		if !contains(surveyTypes, fmt.Sprint(question["type"])) {
+			return fmt.Sprintf("'%v' in survey question %d is not one of '%v' allowed question types.", question["type"], i, surveyTypes)
+		}
+		variable := fmt.Sprint(question["variable"])
+		if variables[variable] {
+			return fmt.Sprintf("'variable' '%s' duplicated in survey question %d.", variable, i)
+		}
+		variables[variable] = true
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// namedURLEndpoints maps the attributes referencing other objects to the API
+// endpoint resolving their named URL.
+var namedURLEndpoints = map[string]string{
+	"credential_id":                 "credentials",
+	"inventory_id":                  "inventories",
+	"job_template_id":               "job_templates",
+	"notification_template_id":      "notification_templates",
+	"organization_id":               "organizations",
+	"workflow_job_template_id":      "workflow_job_templates",
+	"workflow_job_template_node_id": "workflow_job_template_nodes",
+}
+
+// resolveNamedURL returns the ID of the object an AWX named URL identifies
+// under endpoint, e.g. `Deploy++Engineering` under `job_templates`.
+func resolveNamedURL(client *awxClient, endpoint, namedURL string) (int, error) {
+	result := new(struct {
+		ID int `json:"id"`
+	})
+	err := client.getJSON(fmt.Sprintf("/api/v2/%s/%s/", endpoint, url.PathEscape(namedURL)), result, map[string]string{})
+	if err != nil {
+		return 0, fmt.Errorf("unable to resolve the %s named URL %q: %w", endpoint, namedURL, err)
+	}
+	return result.ID, nil
+}
+
+// resolveIDOrNamedURL returns id when it is numeric, and resolves it as a
+// named URL under endpoint otherwise.
+func resolveIDOrNamedURL(client *awxClient, endpoint, id string) (int, error) {
+	if v, err := strconv.Atoi(id); err == nil {
+		return v, nil
+	}
+	return resolveNamedURL(client, endpoint, id)
+}
+
+// importStateNamedURL imports an object from its numeric ID or its named URL.
+func importStateNamedURL(endpoint string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		id, err := resolveIDOrNamedURL(m.(*awxClient), endpoint, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(strconv.Itoa(id))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// dataSourceNamedURLSchema is the named_url argument data sources accept to
+// look an object up the same way on every controller.
+func dataSourceNamedURLSchema(example string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: fmt.Sprintf("AWX named URL of the object, e.g. `%s`", example),
+	}
+}
+
+// setIDFromNamedURL resolves the named_url argument of a data source into
+// idAttribute, the lookup then carries on as a lookup by ID.
+func setIDFromNamedURL(d *schema.ResourceData, m interface{}, endpoint, idAttribute string) diag.Diagnostics {
+	namedURL, ok := d.GetOk("named_url")
+	if !ok {
+		return nil
+	}
+	id, err := resolveNamedURL(m.(*awxClient), endpoint, namedURL.(string))
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to resolve named_url",
+			"Fail to find the object named %s, got: %s",
+			namedURL.(string), err.Error(),
+		)
+	}
+	d.Set(idAttribute, id)
+	return nil
+}
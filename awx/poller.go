@@ -0,0 +1,52 @@
+package awx
+
+import (
+	"context"
+	"time"
+
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Statuses of AWX unified jobs: jobs, project updates and inventory updates.
+var (
+	jobPendingStatuses  = []string{"new", "pending", "waiting", "running"}
+	jobFinishedStatuses = []string{"successful", "failed", "error", "canceled"}
+)
+
+const (
+	jobPollDelay      = 3 * time.Second
+	jobPollMinTimeout = 3 * time.Second
+)
+
+// jobStatusFunc reads the status of a unified job through get, e.g. the
+// JobService GetJob method.
+func jobStatusFunc(get func(id int) (*awx.Job, error), id int) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		job, err := get(id)
+		if err != nil {
+			return nil, "", err
+		}
+		return job, job.Status, nil
+	}
+}
+
+// waitForJob polls refresh until the job reaches one of target statuses. It
+// gives up with an error when the job ends in another status, when timeout
+// elapses or when ctx is cancelled, e.g. on an interrupted apply.
+func waitForJob(ctx context.Context, refresh retry.StateRefreshFunc, timeout time.Duration, target ...string) (*awx.Job, error) {
+	stateConf := &retry.StateChangeConf{
+		Pending:    jobPendingStatuses,
+		Target:     target,
+		Refresh:    refresh,
+		Timeout:    timeout,
+		Delay:      jobPollDelay,
+		MinTimeout: jobPollMinTimeout,
+	}
+
+	job, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return job.(*awx.Job), nil
+}
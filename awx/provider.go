@@ -2,12 +2,13 @@ package awx
 
 import (
 	"context"
-	"crypto/tls"
-	"net/http"
+	"fmt"
+	"log"
 
 	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -16,33 +17,174 @@ func Provider() *schema.Provider {
 			"hostname": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_HOSTNAME", "http://localhost"),
+				Description: "The API endpoint for AWX. Falls back to AWX_HOSTNAME, CONTROLLER_HOST, TOWER_HOST, the config file, then http://localhost",
+			},
+			"api_base_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_API_BASE_PATH", ""),
+				Description: "Base path of the controller API, e.g. /api/v2/ for AWX or /api/controller/v2/ behind the AAP platform gateway. Detected from the /api/ listing when empty",
 			},
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     false,
-				Description: "Disable SSL verification of API calls",
+				Description: "Disable SSL verification of API calls. Falls back to AWX_INSECURE, CONTROLLER_VERIFY_SSL, TOWER_VERIFY_SSL, the config file, then false",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CA_CERT_FILE", ""),
+				Description: "Path to a PEM encoded CA bundle used to verify the AWX certificate, in addition to the system trust store",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CA_CERT_PEM", ""),
+				Description: "PEM encoded CA bundle used to verify the AWX certificate, in addition to the system trust store",
+			},
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CLIENT_CERT", ""),
+				Description: "PEM encoded client certificate, or path to it, presented to mutual TLS endpoints",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CLIENT_KEY", ""),
+				Description: "PEM encoded private key of the client certificate, or path to it",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_TLS_SERVER_NAME", ""),
+				Description: "Server name used to verify the AWX certificate, when it differs from the hostname",
 			},
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", "admin"),
+				Description: "The username for API access. Falls back to AWX_USERNAME, CONTROLLER_USERNAME, TOWER_USERNAME, the config file, then admin",
 			},
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", "password"),
+				Description: "The password for API access. Falls back to AWX_PASSWORD, CONTROLLER_PASSWORD, TOWER_PASSWORD, the config file, then password",
 			},
 			"token": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_TOKEN", ""),
+				Description: "The AWX token for API access. Falls back to AWX_TOKEN, CONTROLLER_OAUTH_TOKEN, TOWER_OAUTH_TOKEN, then the oauth_token of the config file",
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CONFIG_FILE", ""),
+				Description: "Path to a tower-cli style config file. Defaults to the first of ./.tower_cli.cfg, ~/.tower_cli.cfg and /etc/tower/tower_cli.cfg found",
+			},
+			"config_profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CONFIG_PROFILE", defaultConfigProfile),
+				Description: "Section of the config file to read the connection settings from",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CLIENT_ID", ""),
+				Description: "Client ID of an AWX OAuth2 application. When set, the credentials are exchanged for a token at configure time, and the token is revoked when the provider shuts down",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_CLIENT_SECRET", ""),
+				Description: "Client secret of a confidential AWX OAuth2 application",
+			},
+			"oauth2_grant_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AWX_OAUTH2_GRANT_TYPE", oauth2GrantTypePassword),
+				ValidateFunc: validation.StringInSlice([]string{oauth2GrantTypePassword, oauth2GrantTypeClientCredentials}, false),
+				Description:  "OAuth2 grant used to obtain a token with client_id: password (exchanges username and password) or client_credentials",
+			},
+			"oauth2_scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AWX_OAUTH2_SCOPE", "write"),
+				ValidateFunc: validation.StringInSlice([]string{"read", "write"}, false),
+				Description:  "Scope of the OAuth2 token obtained with client_id",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_MAX_RETRIES", defaultMaxRetries),
+				Description: "Maximum number of retries of an API call failing with a transient error (429, 502, 503, 504 or a connection error). Set to 0 to disable retries",
+			},
+			"max_retry_wait": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_MAX_RETRY_WAIT", defaultMaxRetryWait),
+				Description: "Maximum number of seconds to wait between two retries, including the delay requested by a Retry-After header",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_PROXY_URL", ""),
+				Description: "URL of the HTTP proxy used to reach AWX, credentials can be given as user:password@ in the URL. Defaults to the HTTP_PROXY and HTTPS_PROXY environment variables",
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_NO_PROXY", ""),
+				Description: "Comma separated list of hosts, domains and CIDRs reached without proxy, whether set by proxy_url or by the HTTP_PROXY and HTTPS_PROXY environment variables. Replaces the NO_PROXY environment variable",
+			},
+			"request_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_REQUEST_TIMEOUT", defaultRequestTimeout),
+				Description: "Maximum number of seconds a single API call attempt may take. Set to 0 to disable the timeout",
+			},
+			"max_idle_conns": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_MAX_IDLE_CONNS", defaultMaxIdleConns),
+				Description: "Maximum number of idle connections kept open to the AWX API",
+			},
+			"idle_conn_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_IDLE_CONN_TIMEOUT", defaultIdleConnTimeout),
+				Description: "Number of seconds an idle connection to the AWX API is kept open",
+			},
+			"max_conns_per_host": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_MAX_CONNS_PER_HOST", 0),
+				Description: "Maximum number of connections opened to the AWX API, 0 means no limit",
+			},
+			"max_requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_MAX_REQUESTS_PER_SECOND", 0),
+				Description: "Maximum number of API calls sent per second by this provider instance, 0 means no limit",
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_MAX_CONCURRENT_REQUESTS", 0),
+				Description: "Maximum number of API calls in flight at the same time for this provider instance, 0 means no limit",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_ADOPT_EXISTING", false),
+				Description: "Take over the objects matching the name, and organization or inventory, of a resource being created instead of failing on duplicates. The object is updated to the configuration",
 			},
 		},
-		ResourcesMap: map[string]*schema.Resource{
+		ResourcesMap: scopeAPIFieldDiagnostics(map[string]*schema.Resource{
 			"awx_credential_azure_key_vault":                          resourceCredentialAzureKeyVault(),
 			"awx_credential_google_compute_engine":                    resourceCredentialGoogleComputeEngine(),
 			"awx_credential_input_source":                             resourceCredentialInputSource(),
@@ -59,7 +201,6 @@ func Provider() *schema.Provider {
 			"awx_inventory_source":                                    resourceInventorySource(),
 			"awx_inventory":                                           resourceInventory(),
 			"awx_job_template_credential":                             resourceJobTemplateCredentials(),
-			"awx_job_template":                                        resourceJobTemplate(),
 			"awx_job_template_launch":                                 resourceJobTemplateLaunch(),
 			"awx_job_template_notification_template_error":            resourceJobTemplateNotificationTemplateError(),
 			"awx_job_template_notification_template_started":          resourceJobTemplateNotificationTemplateStarted(),
@@ -73,17 +214,14 @@ func Provider() *schema.Provider {
 			"awx_setting":                                             resourceSetting(),
 			"awx_team":                                                resourceTeam(),
 			"awx_user":                                                resourceUser(),
-			"awx_workflow_job_template_node_always":                   resourceWorkflowJobTemplateNodeAlways(),
-			"awx_workflow_job_template_node_failure":                  resourceWorkflowJobTemplateNodeFailure(),
-			"awx_workflow_job_template_node_success":                  resourceWorkflowJobTemplateNodeSuccess(),
-			"awx_workflow_job_template_node":                          resourceWorkflowJobTemplateNode(),
 			"awx_workflow_job_template":                               resourceWorkflowJobTemplate(),
 			"awx_workflow_job_template_schedule":                      resourceWorkflowJobTemplateSchedule(),
 			"awx_workflow_job_template_notification_template_error":   resourceWorkflowJobTemplateNotificationTemplateError(),
 			"awx_workflow_job_template_notification_template_started": resourceWorkflowJobTemplateNotificationTemplateStarted(),
 			"awx_workflow_job_template_notification_template_success": resourceWorkflowJobTemplateNotificationTemplateSuccess(),
-		},
+		}),
 		DataSourcesMap: map[string]*schema.Resource{
+			"awx_config":                     dataSourceConfig(),
 			"awx_credential_azure_key_vault": dataSourceCredentialAzure(),
 			"awx_credential":                 dataSourceCredentialByID(),
 			"awx_credential_type":            dataSourceCredentialTypeByID(),
@@ -108,27 +246,75 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	hostname := d.Get("hostname").(string)
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
-	token := d.Get("token").(string)
-
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	client := http.DefaultClient
-	if d.Get("insecure").(bool) {
-		customTransport := http.DefaultTransport.(*http.Transport).Clone()
-		customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-		client.Transport = customTransport
+	settings, err := resolveConnectionSettings(d)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid connection settings",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+	hostname := settings.Hostname
+	username := settings.Username
+	password := settings.Password
+	token := settings.Token
+
+	client, err := newHTTPClient(ctx, d, settings.Insecure)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid HTTP client configuration",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+	client.Transport, err = newCassetteTransport(client.Transport)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid cassette configuration",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+
+	apiBasePath := resolveAPIBasePath(ctx, client, hostname, d.Get("api_base_path").(string))
+	log.Printf("[DEBUG] using controller API base path %s", apiBasePath)
+	client.Transport = newBasePathTransport(client.Transport, hostname, apiBasePath)
+
+	if clientID := d.Get("client_id").(string); token == "" && clientID != "" {
+		creds := oauth2Credentials{
+			GrantType:    d.Get("oauth2_grant_type").(string),
+			ClientID:     clientID,
+			ClientSecret: d.Get("client_secret").(string),
+			Username:     username,
+			Password:     password,
+			Scope:        d.Get("oauth2_scope").(string),
+		}
+		token, err = exchangeOAuth2Token(ctx, client, hostname, apiBasePath, creds)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to obtain an OAuth2 token",
+				Detail:   fmt.Sprintf("Unable to exchange the %s credentials of client %s for a token: %s", creds.GrantType, clientID, err.Error()),
+			})
+			return nil, diags
+		}
+		revokeOAuth2TokenOnShutdown(client, hostname, apiBasePath, creds, token)
 	}
 
 	var c *awx.AWX
-	var err error
+	var authenticator awx.Authenticator
 	if token != "" {
 		c, err = awx.NewAWXToken(hostname, token, client)
+		authenticator = &awx.TokenAuth{Token: token}
 	} else {
 		c, err = awx.NewAWX(hostname, username, password, client)
+		authenticator = &awx.BasicAuth{Username: username, Password: password}
 	}
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -139,5 +325,18 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diags
 	}
 
-	return c, diags
+	meta := newAWXClient(c, hostname, authenticator, client)
+	meta.adoptExisting = d.Get("adopt_existing").(bool)
+	meta.controller, err = fetchControllerInfo(meta)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to detect the controller version",
+			Detail:   fmt.Sprintf("Version specific attributes are not checked: %s", err.Error()),
+		})
+	} else {
+		log.Printf("[DEBUG] connected to controller version %s", meta.controller.Version)
+	}
+
+	return meta, diags
 }
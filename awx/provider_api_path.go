@@ -0,0 +1,111 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// awxAPIBasePath is the API root goawx builds every endpoint from.
+const awxAPIBasePath = "/api/v2/"
+
+// apiRootResponse holds the fields of the `/api/` listings used to find the
+// controller API: AWX and standalone controllers answer with current_version,
+// the AAP 2.5+ platform gateway lists the APIs of each service.
+type apiRootResponse struct {
+	CurrentVersion string            `json:"current_version"`
+	APIs           map[string]string `json:"apis"`
+}
+
+// resolveAPIBasePath returns the base path of the controller API. The
+// configured value wins, otherwise the path is detected from the `/api/`
+// listing and defaults to the AWX layout when detection fails.
+func resolveAPIBasePath(ctx context.Context, client *http.Client, hostname, configured string) string {
+	if configured != "" {
+		return normalizeAPIBasePath(configured)
+	}
+
+	root, err := getAPIRoot(ctx, client, hostname, "/api/")
+	if err != nil {
+		log.Printf("[WARN] unable to detect the controller API base path, using %s: %s", awxAPIBasePath, err)
+		return awxAPIBasePath
+	}
+	if root.CurrentVersion != "" {
+		return normalizeAPIBasePath(root.CurrentVersion)
+	}
+
+	controller, ok := root.APIs["controller"]
+	if !ok {
+		log.Printf("[WARN] no controller API listed by %s/api/, using %s", hostname, awxAPIBasePath)
+		return awxAPIBasePath
+	}
+	controllerRoot, err := getAPIRoot(ctx, client, hostname, controller)
+	if err == nil && controllerRoot.CurrentVersion != "" {
+		return normalizeAPIBasePath(controllerRoot.CurrentVersion)
+	}
+	return normalizeAPIBasePath(strings.TrimSuffix(controller, "/") + "/v2/")
+}
+
+func getAPIRoot(ctx context.Context, client *http.Client, hostname, path string) (*apiRootResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(hostname, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with %d", path, resp.StatusCode)
+	}
+	root := new(apiRootResponse)
+	if err := json.NewDecoder(resp.Body).Decode(root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func normalizeAPIBasePath(path string) string {
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" {
+		path = u.Path
+	}
+	return "/" + strings.Trim(path, "/") + "/"
+}
+
+// basePathTransport moves the AWX API endpoints used by goawx under the
+// controller API base path, e.g. `/api/controller/v2/` behind the gateway.
+type basePathTransport struct {
+	next     http.RoundTripper
+	from, to string
+}
+
+func newBasePathTransport(next http.RoundTripper, hostname, basePath string) http.RoundTripper {
+	if basePath == awxAPIBasePath {
+		return next
+	}
+	prefix := ""
+	if u, err := url.Parse(hostname); err == nil {
+		prefix = strings.TrimSuffix(u.Path, "/")
+	}
+	return &basePathTransport{
+		next: next,
+		from: prefix + awxAPIBasePath,
+		to:   prefix + basePath,
+	}
+}
+
+func (t *basePathTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the pagination links given back by the controller are already moved
+	if !strings.HasPrefix(req.URL.Path, t.from) || strings.HasPrefix(req.URL.Path, t.to) {
+		return t.next.RoundTrip(req)
+	}
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Path = t.to + strings.TrimPrefix(req.URL.Path, t.from)
+	rewritten.URL.RawPath = ""
+	return t.next.RoundTrip(rewritten)
+}
@@ -0,0 +1,216 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	awx "github.com/denouche/goawx/client"
+)
+
+func TestNormalizeAPIBasePath(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v2/", "/api/v2/"},
+		{"/api/controller/v2", "/api/controller/v2/"},
+		{"api/controller/v2/", "/api/controller/v2/"},
+		{"api/v2", "/api/v2/"},
+		{"//api/v2//", "/api/v2/"},
+		{"https://aap.example.com/api/controller/v2/", "/api/controller/v2/"},
+		{"/tower/api/v2/", "/tower/api/v2/"},
+	} {
+		if path := normalizeAPIBasePath(tc.path); path != tc.expected {
+			t.Errorf("normalizeAPIBasePath(%q) = %q, expected %q", tc.path, path, tc.expected)
+		}
+	}
+}
+
+func TestResolveAPIBasePath(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		configured string
+		listings   map[string]string
+		expected   string
+	}{
+		{
+			name:       "configured",
+			configured: "api/controller/v2",
+			listings:   map[string]string{"/api/": `{"current_version": "/api/v2/"}`},
+			expected:   "/api/controller/v2/",
+		},
+		{
+			name:     "AWX",
+			listings: map[string]string{"/api/": `{"current_version": "/api/v2/"}`},
+			expected: "/api/v2/",
+		},
+		{
+			name:     "current version without slashes",
+			listings: map[string]string{"/api/": `{"current_version": "api/v2"}`},
+			expected: "/api/v2/",
+		},
+		{
+			name: "platform gateway",
+			listings: map[string]string{
+				"/api/":            `{"apis": {"gateway": "/api/gateway/", "controller": "/api/controller/"}}`,
+				"/api/controller/": `{"current_version": "/api/controller/v2/"}`,
+			},
+			expected: "/api/controller/v2/",
+		},
+		{
+			name:     "platform gateway without controller listing",
+			listings: map[string]string{"/api/": `{"apis": {"controller": "/api/controller"}}`},
+			expected: "/api/controller/v2/",
+		},
+		{
+			name:     "platform gateway without controller",
+			listings: map[string]string{"/api/": `{"apis": {"gateway": "/api/gateway/"}}`},
+			expected: awxAPIBasePath,
+		},
+		{
+			name:     "no listing",
+			listings: map[string]string{},
+			expected: awxAPIBasePath,
+		},
+		{
+			name:     "invalid listing",
+			listings: map[string]string{"/api/": `<html>maintenance</html>`},
+			expected: awxAPIBasePath,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requested []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = append(requested, r.URL.Path)
+				listing, ok := tc.listings[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Write([]byte(listing))
+			}))
+			defer server.Close()
+
+			// the trailing slash of the hostname is not doubled
+			path := resolveAPIBasePath(context.Background(), server.Client(), server.URL+"/", tc.configured)
+			if path != tc.expected {
+				t.Errorf("resolved as %q, expected %q", path, tc.expected)
+			}
+			if tc.configured != "" && len(requested) != 0 {
+				t.Errorf("configured path detected with %v", requested)
+			}
+			for _, p := range requested {
+				if strings.Contains(p, "//") {
+					t.Errorf("requested %s", p)
+				}
+			}
+		})
+	}
+}
+
+func TestBasePathTransport(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		hostname string
+		basePath string
+		path     string
+		expected string
+	}{
+		{"AWX layout untouched", "https://awx.example.com", "/api/v2/", "/api/v2/ping/", "/api/v2/ping/"},
+		{"gateway", "https://aap.example.com", "/api/controller/v2/", "/api/v2/job_templates/", "/api/controller/v2/job_templates/"},
+		{"already moved", "https://aap.example.com", "/api/controller/v2/", "/api/controller/v2/job_templates/", "/api/controller/v2/job_templates/"},
+		{"outside of the API", "https://aap.example.com", "/api/controller/v2/", "/api/o/token/", "/api/o/token/"},
+		{"hostname with a path", "https://example.com/aap/", "/api/controller/v2/", "/aap/api/v2/ping/", "/aap/api/controller/v2/ping/"},
+		{"custom prefix", "https://awx.example.com", "/custom/api/v2/", "/api/v2/ping/", "/custom/api/v2/ping/"},
+		{"custom prefix extending the AWX layout", "https://awx.example.com", "/api/v2/awx/", "/api/v2/awx/ping/", "/api/v2/awx/ping/"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent string
+			next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sent = req.URL.Path
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+			u, _ := url.Parse(tc.hostname)
+			req, _ := http.NewRequest(http.MethodGet, u.Scheme+"://"+u.Host+tc.path, nil)
+			if _, err := newBasePathTransport(next, tc.hostname, tc.basePath).RoundTrip(req); err != nil {
+				t.Fatal(err)
+			}
+			if sent != tc.expected {
+				t.Errorf("sent to %s, expected %s", sent, tc.expected)
+			}
+			if req.URL.Path != tc.path {
+				t.Errorf("request changed to %s", req.URL.Path)
+			}
+		})
+	}
+}
+
+// TestBasePathTransportPagination lists organizations through goawx behind a
+// gateway whose pagination links are already under the controller path.
+func TestBasePathTransportPagination(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/controller/v2/ping/":
+			json.NewEncoder(w).Encode(map[string]string{"version": "4.6.0"})
+		case "/api/controller/v2/organizations/":
+			page := r.URL.Query().Get("page")
+			if page == "" {
+				page = "1"
+			}
+			var next interface{}
+			if page != "3" {
+				next = fmt.Sprintf("/api/controller/v2/organizations/?page=%c", page[0]+1)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"count":   3,
+				"next":    next,
+				"results": []map[string]interface{}{{"id": page[0] - '0', "name": "org" + page}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newBasePathTransport(http.DefaultTransport, server.URL, "/api/controller/v2/")}
+	c, err := awx.NewAWXToken(server.URL, "test", client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgs, err := c.OrganizationsService.ListOrganizations(map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 3 {
+		t.Errorf("listed %d organizations, expected 3", len(orgs))
+	}
+
+	expected := []string{
+		"/api/controller/v2/ping/",
+		"/api/controller/v2/organizations/",
+		"/api/controller/v2/organizations/",
+		"/api/controller/v2/organizations/",
+	}
+	if strings.Join(requested, " ") != strings.Join(expected, " ") {
+		t.Errorf("requested %v, expected %v", requested, expected)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
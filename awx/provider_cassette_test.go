@@ -0,0 +1,284 @@
+package awx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// cassetteFixtures holds the cassettes recorded against AWX releases, one
+// directory per release, e.g. `awx-23.3.1`, and one cassette per scenario.
+const cassetteFixtures = "../test/fixtures"
+
+// cassetteScenario is a configuration applied to a recorded AWX, checked
+// against the state the provider builds from the AWX responses.
+type cassetteScenario struct {
+	name   string
+	config string
+	check  resource.TestCheckFunc
+}
+
+var cassetteScenarios = []cassetteScenario{
+	{
+		name: "organization",
+		config: `
+resource "awx_organization" "test" {
+  name        = "cassette-organization"
+  description = "recorded"
+  max_hosts   = 10
+}
+`,
+		check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("awx_organization.test", "name", "cassette-organization"),
+			resource.TestCheckResourceAttr("awx_organization.test", "description", "recorded"),
+			resource.TestCheckResourceAttr("awx_organization.test", "max_hosts", "10"),
+		),
+	},
+	{
+		name: "project",
+		config: `
+resource "awx_organization" "test" {
+  name = "cassette-project"
+}
+
+resource "awx_credential_scm" "test" {
+  name            = "cassette-project"
+  organization_id = awx_organization.test.id
+  username        = "git"
+  password        = "cassette-secret"
+}
+
+resource "awx_project" "test" {
+  name              = "cassette-project"
+  organization_id   = awx_organization.test.id
+  scm_type          = "git"
+  scm_url           = "https://github.com/ansible/ansible-tower-samples"
+  scm_branch        = "master"
+  scm_credential_id = awx_credential_scm.test.id
+}
+`,
+		check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrPair("awx_project.test", "organization_id", "awx_organization.test", "id"),
+			resource.TestCheckResourceAttrPair("awx_project.test", "scm_credential_id", "awx_credential_scm.test", "id"),
+			resource.TestCheckResourceAttr("awx_project.test", "scm_branch", "master"),
+		),
+	},
+	{
+		name: "inventory",
+		config: `
+resource "awx_organization" "test" {
+  name = "cassette-inventory"
+}
+
+resource "awx_inventory" "test" {
+  name            = "cassette-inventory"
+  organization_id = awx_organization.test.id
+  variables       = "foo: bar"
+}
+
+resource "awx_inventory_group" "test" {
+  name         = "cassette-inventory"
+  inventory_id = awx_inventory.test.id
+}
+
+resource "awx_host" "test" {
+  name         = "cassette-inventory"
+  inventory_id = awx_inventory.test.id
+  group_ids    = [awx_inventory_group.test.id]
+  enabled      = true
+}
+`,
+		check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrPair("awx_inventory.test", "organization_id", "awx_organization.test", "id"),
+			resource.TestCheckResourceAttrPair("awx_inventory_group.test", "inventory_id", "awx_inventory.test", "id"),
+			resource.TestCheckResourceAttrPair("awx_host.test", "inventory_id", "awx_inventory.test", "id"),
+			resource.TestCheckResourceAttr("awx_host.test", "enabled", "true"),
+		),
+	},
+	{
+		name: "team",
+		config: `
+resource "awx_organization" "test" {
+  name = "cassette-team"
+}
+
+data "awx_organization_role" "member" {
+  name            = "Member"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_team" "test" {
+  name            = "cassette-team"
+  organization_id = awx_organization.test.id
+
+  role_entitlement {
+    role_id = data.awx_organization_role.member.id
+  }
+}
+`,
+		check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrPair("awx_team.test", "organization_id", "awx_organization.test", "id"),
+			resource.TestCheckTypeSetElemAttrPair("awx_team.test", "role_entitlement.*.role_id", "data.awx_organization_role.member", "id"),
+		),
+	},
+	{
+		name: "job_template",
+		config: `
+resource "awx_organization" "test" {
+  name = "cassette-job-template"
+}
+
+resource "awx_inventory" "test" {
+  name            = "cassette-job-template"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_credential_scm" "test" {
+  name            = "cassette-job-template-scm"
+  organization_id = awx_organization.test.id
+  username        = "git"
+  password        = "cassette-secret"
+}
+
+resource "awx_project" "test" {
+  name              = "cassette-job-template"
+  organization_id   = awx_organization.test.id
+  scm_type          = "git"
+  scm_url           = "https://github.com/ansible/ansible-tower-samples"
+  scm_credential_id = awx_credential_scm.test.id
+}
+
+resource "awx_credential_machine" "test" {
+  name            = "cassette-job-template-machine"
+  organization_id = awx_organization.test.id
+  username        = "root"
+  password        = "cassette-secret"
+}
+
+resource "awx_job_template" "test" {
+  name         = "cassette-job-template"
+  job_type     = "run"
+  inventory_id = awx_inventory.test.id
+  project_id   = awx_project.test.id
+  playbook     = "hello_world.yml"
+  extra_vars   = "foo: bar"
+}
+
+resource "awx_job_template_credential" "test" {
+  job_template_id = awx_job_template.test.id
+  credential_id   = awx_credential_machine.test.id
+}
+`,
+		check: resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrPair("awx_project.test", "scm_credential_id", "awx_credential_scm.test", "id"),
+			resource.TestCheckResourceAttr("awx_credential_scm.test", "username", "git"),
+			resource.TestCheckResourceAttr("awx_credential_machine.test", "username", "root"),
+			resource.TestCheckResourceAttrPair("awx_job_template.test", "project_id", "awx_project.test", "id"),
+			resource.TestCheckResourceAttrPair("awx_job_template.test", "inventory_id", "awx_inventory.test", "id"),
+			resource.TestCheckResourceAttr("awx_job_template.test", "playbook", "hello_world.yml"),
+			resource.TestCheckResourceAttrPair("awx_job_template_credential.test", "credential_id", "awx_credential_machine.test", "id"),
+		),
+	},
+}
+
+// TestCassettes replays every scenario against the cassettes recorded for it
+// under test/fixtures, and fails until some are: a release directory lacking
+// one of the scenarios fails too. With AWX_CASSETTE_MODE set to `record`, the
+// scenarios are recorded instead, under the release named by
+// AWX_CASSETTE_RELEASE, against the AWX the AWX_* variables point to.
+func TestCassettes(t *testing.T) {
+	if os.Getenv(cassetteModeEnv) == cassetteModeRecord {
+		release := os.Getenv("AWX_CASSETTE_RELEASE")
+		if release == "" || os.Getenv("AWX_HOSTNAME") == "" {
+			t.Fatal("recording cassettes needs AWX_CASSETTE_RELEASE to name the AWX release AWX_HOSTNAME points to")
+		}
+		for _, scenario := range cassetteScenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				testCassetteScenario(t, filepath.Join(cassetteFixtures, release, scenario.name+".json"), cassetteModeRecord, `provider "awx" {}`, scenario)
+			})
+		}
+		return
+	}
+
+	releases, err := os.ReadDir(cassetteFixtures)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	replayed := false
+	for _, release := range releases {
+		if !release.IsDir() {
+			continue
+		}
+		for _, scenario := range cassetteScenarios {
+			path := filepath.Join(cassetteFixtures, release.Name(), scenario.name+".json")
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("no %s cassette recorded for %s: %s", scenario.name, release.Name(), err)
+				continue
+			}
+			replayed = true
+			t.Run(release.Name()+"/"+scenario.name, func(t *testing.T) {
+				testCassetteScenario(t, path, cassetteModeReplay, cassetteReplayProvider, scenario)
+			})
+		}
+	}
+	if !replayed {
+		t.Fatalf("no cassette recorded under %s, record them with AWX_CASSETTE_MODE=record", cassetteFixtures)
+	}
+}
+
+// TestCassetteScenarios records every scenario against the fake AWX and
+// replays it, checking the scenarios and the redaction of their secrets
+// before they are recorded against a real AWX.
+func TestCassetteScenarios(t *testing.T) {
+	for _, scenario := range cassetteScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), scenario.name+".json")
+			testCassetteScenario(t, path, cassetteModeRecord, testProviderConfig(newTestServer(t), ""), scenario)
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(content), "cassette-secret") {
+				t.Errorf("the cassette %s holds a secret", path)
+			}
+			testCassetteScenario(t, path, cassetteModeReplay, cassetteReplayProvider, scenario)
+		})
+	}
+}
+
+// cassetteReplayProvider configures the provider to replay a cassette,
+// nothing listens there, every response comes from the cassette.
+const cassetteReplayProvider = `
+provider "awx" {
+  hostname    = "https://awx.invalid"
+  token       = "replay"
+  max_retries = 0
+}
+`
+
+func testCassetteScenario(t *testing.T, path, mode, provider string, scenario cassetteScenario) {
+	t.Setenv(cassetteEnv, path)
+	t.Setenv(cassetteModeEnv, mode)
+	forgetCassette(path)
+	t.Cleanup(func() {
+		forgetCassette(path)
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: provider + scenario.config,
+				Check:  scenario.check,
+			},
+			{
+				Config:   provider + scenario.config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
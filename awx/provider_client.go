@@ -0,0 +1,63 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	awx "github.com/denouche/goawx/client"
+)
+
+// awxClient is the meta handed to every resource and data source: the goawx
+// services, a requester for the endpoints goawx does not cover, and what the
+// provider learnt about the controller at configure time.
+type awxClient struct {
+	*awx.AWX
+
+	requester     *awx.Requester
+	controller    *controllerInfo
+	adoptExisting bool
+}
+
+func newAWXClient(c *awx.AWX, hostname string, authenticator awx.Authenticator, client *http.Client) *awxClient {
+	return &awxClient{
+		AWX: c,
+		requester: &awx.Requester{
+			Base:          hostname,
+			Authenticator: authenticator,
+			Client:        client,
+		},
+	}
+}
+
+// getJSON reads an endpoint goawx has no service method for. Failures are
+// reported the way goawx reports them, so isNotFoundError applies.
+func (c *awxClient) getJSON(endpoint string, result interface{}, params map[string]string) error {
+	resp, err := c.requester.GetJSON(endpoint, result, params)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
+
+// postJSON sends data to an endpoint goawx has no service method for.
+func (c *awxClient) postJSON(endpoint string, data interface{}, result interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	resp, err := c.requester.PostJSON(endpoint, bytes.NewReader(payload), result, nil)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
+
+// delete deletes an endpoint goawx has no service method for.
+func (c *awxClient) delete(endpoint string) error {
+	resp, err := c.requester.Delete(endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
@@ -0,0 +1,229 @@
+package awx
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const defaultConfigProfile = "general"
+
+// connectionSetting lists where a connection attribute is looked up when it
+// is not set in the provider block: the environment variables in order, then
+// the key of the tower-cli config file, then the fallback value.
+type connectionSetting struct {
+	envVars  []string
+	fileKey  string
+	fallback string
+}
+
+var connectionSettingSources = map[string]connectionSetting{
+	"hostname": {
+		envVars:  []string{"AWX_HOSTNAME", "CONTROLLER_HOST", "TOWER_HOST"},
+		fileKey:  "host",
+		fallback: "http://localhost",
+	},
+	"username": {
+		envVars:  []string{"AWX_USERNAME", "CONTROLLER_USERNAME", "TOWER_USERNAME"},
+		fileKey:  "username",
+		fallback: "admin",
+	},
+	"password": {
+		envVars:  []string{"AWX_PASSWORD", "CONTROLLER_PASSWORD", "TOWER_PASSWORD"},
+		fileKey:  "password",
+		fallback: "password",
+	},
+	"token": {
+		envVars: []string{"AWX_TOKEN", "CONTROLLER_OAUTH_TOKEN", "TOWER_OAUTH_TOKEN"},
+		fileKey: "oauth_token",
+	},
+}
+
+// connectionSettings holds the resolved connection attributes of the provider.
+type connectionSettings struct {
+	Hostname string
+	Username string
+	Password string
+	Token    string
+	Insecure bool
+}
+
+// resolveConnectionSettings resolves the connection attributes, in order of
+// precedence, from the provider block, the AWX_* environment variables, the
+// CONTROLLER_* and TOWER_* environment variables shared with the awx CLI and
+// the awx.awx collection, and the selected profile of the tower-cli config file.
+func resolveConnectionSettings(d *schema.ResourceData) (*connectionSettings, error) {
+	profile, err := loadConfigProfile(d.Get("config_file").(string), d.Get("config_profile").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	resolve := func(attribute string) string {
+		if v, ok := configuredValue(d, attribute); ok {
+			return v.(string)
+		}
+		source := connectionSettingSources[attribute]
+		for _, name := range source.envVars {
+			if v := os.Getenv(name); v != "" {
+				return v
+			}
+		}
+		if v, ok := profile[source.fileKey]; ok {
+			return v
+		}
+		return source.fallback
+	}
+
+	settings := &connectionSettings{
+		Hostname: normalizeHostname(resolve("hostname")),
+		Username: resolve("username"),
+		Password: resolve("password"),
+		Token:    resolve("token"),
+	}
+
+	settings.Insecure, err = resolveInsecure(d, profile)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// resolveInsecure follows the same precedence as the other connection
+// attributes, the CONTROLLER_VERIFY_SSL, TOWER_VERIFY_SSL and verify_ssl
+// settings having the opposite meaning.
+func resolveInsecure(d *schema.ResourceData, profile map[string]string) (bool, error) {
+	if v, ok := configuredValue(d, "insecure"); ok {
+		return v.(bool), nil
+	}
+	if v := os.Getenv("AWX_INSECURE"); v != "" {
+		return parseConfigBool("AWX_INSECURE", v)
+	}
+	for _, name := range []string{"CONTROLLER_VERIFY_SSL", "TOWER_VERIFY_SSL"} {
+		if v := os.Getenv(name); v != "" {
+			verify, err := parseConfigBool(name, v)
+			return !verify, err
+		}
+	}
+	if v, ok := profile["verify_ssl"]; ok {
+		verify, err := parseConfigBool("verify_ssl", v)
+		return !verify, err
+	}
+	return false, nil
+}
+
+// configuredValue returns the value of attribute when it is set in the
+// provider block.
+func configuredValue(d *schema.ResourceData, attribute string) (interface{}, bool) {
+	raw := d.GetRawConfig()
+	if raw.IsKnown() && !raw.IsNull() {
+		if raw.GetAttr(attribute).IsNull() {
+			return nil, false
+		}
+		return d.Get(attribute), true
+	}
+	return d.GetOk(attribute)
+}
+
+// normalizeHostname adds the https scheme tower-cli assumes for bare hosts.
+func normalizeHostname(hostname string) string {
+	if hostname != "" && !strings.Contains(hostname, "://") {
+		hostname = "https://" + hostname
+	}
+	return strings.TrimSuffix(hostname, "/")
+}
+
+func parseConfigBool(name, value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean %q for %s", value, name)
+	}
+	return b, nil
+}
+
+// configFileSearchPath lists the tower-cli config files, the most specific first.
+func configFileSearchPath() []string {
+	paths := []string{".tower_cli.cfg"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".tower_cli.cfg"))
+	}
+	return append(paths, "/etc/tower/tower_cli.cfg")
+}
+
+// loadConfigProfile returns the settings of the profile section of the
+// tower-cli config file. Without an explicit file, the first existing file of
+// the search path is used, and having none is not an error.
+func loadConfigProfile(file, profile string) (map[string]string, error) {
+	if profile == "" {
+		profile = defaultConfigProfile
+	}
+
+	if file == "" {
+		for _, candidate := range configFileSearchPath() {
+			if _, err := os.Stat(candidate); err == nil {
+				file = candidate
+				break
+			}
+		}
+		if file == "" {
+			return map[string]string{}, nil
+		}
+	}
+
+	sections, err := parseINIFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %s: %w", file, err)
+	}
+	settings, ok := sections[profile]
+	if !ok {
+		if profile != defaultConfigProfile {
+			return nil, fmt.Errorf("profile %s not found in config file %s", profile, file)
+		}
+		settings = map[string]string{}
+	}
+	return settings, nil
+}
+
+// parseINIFile reads the sections of the INI file used by tower-cli.
+func parseINIFile(file string) (map[string]map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sections := map[string]map[string]string{}
+	var current map[string]string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if _, ok := sections[name]; !ok {
+				sections[name] = map[string]string{}
+			}
+			current = sections[name]
+			continue
+		}
+		sep := strings.IndexAny(text, "=:")
+		if sep < 0 || current == nil {
+			return nil, fmt.Errorf("line %d: expected a key = value pair in a section", line)
+		}
+		key := strings.TrimSpace(text[:sep])
+		current[key] = strings.Trim(strings.TrimSpace(text[sep+1:]), `"'`)
+	}
+	return sections, scanner.Err()
+}
@@ -0,0 +1,206 @@
+package awx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// writeConfigFile writes a tower-cli config file in a temporary directory.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "tower_cli.cfg")
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestParseINIFile(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		content  string
+		expected map[string]map[string]string
+		err      bool
+	}{
+		{
+			name:     "empty",
+			content:  "",
+			expected: map[string]map[string]string{},
+		},
+		{
+			name:    "sections",
+			content: "[general]\nhost = awx.example.com\n\n[staging]\nhost = staging.example.com\nusername = deploy\n",
+			expected: map[string]map[string]string{
+				"general": {"host": "awx.example.com"},
+				"staging": {"host": "staging.example.com", "username": "deploy"},
+			},
+		},
+		{
+			name:     "comments",
+			content:  "# tower-cli\n; settings\n[general]\n  # indented\nhost = awx.example.com\n",
+			expected: map[string]map[string]string{"general": {"host": "awx.example.com"}},
+		},
+		{
+			name:    "quoting and separators",
+			content: "[ general ]\npassword = \"s3cr=t\"\ntoken: 'abc'\nverify_ssl=false\n",
+			expected: map[string]map[string]string{
+				"general": {"password": "s3cr=t", "token": "abc", "verify_ssl": "false"},
+			},
+		},
+		{
+			name:     "repeated section",
+			content:  "[general]\nhost = a\n[other]\n[general]\nusername = b\nhost = c\n",
+			expected: map[string]map[string]string{"general": {"host": "c", "username": "b"}, "other": {}},
+		},
+		{
+			name:    "key outside of a section",
+			content: "host = awx.example.com\n",
+			err:     true,
+		},
+		{
+			name:    "line without a value",
+			content: "[general]\nhost\n",
+			err:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sections, err := parseINIFile(writeConfigFile(t, tc.content))
+			if tc.err {
+				if err == nil {
+					t.Errorf("parsed as %v, expected an error", sections)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sections, tc.expected) {
+				t.Errorf("parsed as %v, expected %v", sections, tc.expected)
+			}
+		})
+	}
+}
+
+func TestLoadConfigProfile(t *testing.T) {
+	file := writeConfigFile(t, "[staging]\nhost = staging.example.com\n")
+
+	if settings, err := loadConfigProfile(file, "staging"); err != nil || settings["host"] != "staging.example.com" {
+		t.Errorf("staging profile loaded as %v, %v", settings, err)
+	}
+	if settings, err := loadConfigProfile(file, ""); err != nil || len(settings) != 0 {
+		t.Errorf("missing general profile loaded as %v, %v", settings, err)
+	}
+	if _, err := loadConfigProfile(file, "production"); err == nil {
+		t.Error("missing production profile loaded")
+	}
+	if _, err := loadConfigProfile(filepath.Join(t.TempDir(), "missing.cfg"), ""); err == nil {
+		t.Error("missing config file loaded")
+	}
+}
+
+func TestResolveConnectionSettings(t *testing.T) {
+	file := writeConfigFile(t, `[general]
+host = file.example.com
+username = file
+password = file
+oauth_token = file
+verify_ssl = false
+`)
+	envVars := []string{
+		"AWX_HOSTNAME", "CONTROLLER_HOST", "TOWER_HOST",
+		"AWX_USERNAME", "CONTROLLER_USERNAME", "TOWER_USERNAME",
+		"AWX_PASSWORD", "CONTROLLER_PASSWORD", "TOWER_PASSWORD",
+		"AWX_TOKEN", "CONTROLLER_OAUTH_TOKEN", "TOWER_OAUTH_TOKEN",
+		"AWX_INSECURE", "CONTROLLER_VERIFY_SSL", "TOWER_VERIFY_SSL",
+		"AWX_CONFIG_FILE", "AWX_CONFIG_PROFILE",
+	}
+
+	for _, tc := range []struct {
+		name     string
+		config   map[string]interface{}
+		env      map[string]string
+		expected connectionSettings
+	}{
+		{
+			name:     "fallbacks",
+			config:   map[string]interface{}{},
+			expected: connectionSettings{Hostname: "http://localhost", Username: "admin", Password: "password"},
+		},
+		{
+			name:     "config file",
+			config:   map[string]interface{}{"config_file": file},
+			expected: connectionSettings{Hostname: "https://file.example.com", Username: "file", Password: "file", Token: "file", Insecure: true},
+		},
+		{
+			name:   "TOWER variables over the config file",
+			config: map[string]interface{}{"config_file": file},
+			env: map[string]string{
+				"TOWER_HOST": "tower.example.com", "TOWER_USERNAME": "tower", "TOWER_PASSWORD": "tower",
+				"TOWER_OAUTH_TOKEN": "tower", "TOWER_VERIFY_SSL": "true",
+			},
+			expected: connectionSettings{Hostname: "https://tower.example.com", Username: "tower", Password: "tower", Token: "tower"},
+		},
+		{
+			name:   "CONTROLLER variables over the TOWER ones",
+			config: map[string]interface{}{"config_file": file},
+			env: map[string]string{
+				"TOWER_HOST": "tower.example.com", "CONTROLLER_HOST": "controller.example.com",
+				"TOWER_VERIFY_SSL": "true", "CONTROLLER_VERIFY_SSL": "no",
+			},
+			expected: connectionSettings{Hostname: "https://controller.example.com", Username: "file", Password: "file", Token: "file", Insecure: true},
+		},
+		{
+			name:   "AWX variables over the CONTROLLER ones",
+			config: map[string]interface{}{"config_file": file},
+			env: map[string]string{
+				"CONTROLLER_HOST": "controller.example.com", "AWX_HOSTNAME": "http://awx.example.com/",
+				"CONTROLLER_VERIFY_SSL": "no", "AWX_INSECURE": "false",
+			},
+			expected: connectionSettings{Hostname: "http://awx.example.com", Username: "file", Password: "file", Token: "file"},
+		},
+		{
+			name: "provider block over everything",
+			config: map[string]interface{}{
+				"config_file": file, "hostname": "block.example.com", "username": "block",
+				"password": "block", "token": "block", "insecure": true,
+			},
+			env:      map[string]string{"AWX_HOSTNAME": "awx.example.com", "AWX_USERNAME": "awx", "AWX_INSECURE": "false"},
+			expected: connectionSettings{Hostname: "https://block.example.com", Username: "block", Password: "block", Token: "block", Insecure: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// no config file is found in the search path
+			t.Setenv("HOME", t.TempDir())
+			for _, name := range envVars {
+				t.Setenv(name, "")
+			}
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.config)
+			settings, err := resolveConnectionSettings(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *settings != tc.expected {
+				t.Errorf("resolved as %+v, expected %+v", *settings, tc.expected)
+			}
+		})
+	}
+}
+
+func TestResolveConnectionSettingsInvalidBoolean(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AWX_INSECURE", "")
+	t.Setenv("CONTROLLER_VERIFY_SSL", "maybe")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	if _, err := resolveConnectionSettings(d); err == nil {
+		t.Error("CONTROLLER_VERIFY_SSL=maybe accepted")
+	}
+}
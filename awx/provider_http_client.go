@@ -0,0 +1,154 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	defaultRequestTimeout  = 60
+	defaultMaxIdleConns    = 10
+	defaultIdleConnTimeout = 90
+)
+
+// newHTTPClient builds the HTTP client used by one provider instance. Each
+// instance owns its transport, so aliased providers targeting different
+// controllers never share connections or TLS settings.
+func newHTTPClient(ctx context.Context, d *schema.ResourceData, insecure bool) (*http.Client, error) {
+	tlsConfig, err := buildTLSConfig(d, insecure)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := proxyFunc(d.Get("proxy_url").(string), d.Get("no_proxy").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = proxy
+	transport.MaxIdleConns = d.Get("max_idle_conns").(int)
+	transport.MaxIdleConnsPerHost = d.Get("max_idle_conns").(int)
+	transport.MaxConnsPerHost = d.Get("max_conns_per_host").(int)
+	transport.IdleConnTimeout = time.Duration(d.Get("idle_conn_timeout").(int)) * time.Second
+
+	var roundTripper http.RoundTripper = transport
+	if timeout := d.Get("request_timeout").(int); timeout > 0 {
+		roundTripper = &timeoutTransport{
+			next:    roundTripper,
+			timeout: time.Duration(timeout) * time.Second,
+		}
+	}
+	// waiting for the limiter does not count against request_timeout
+	roundTripper = newLimitTransport(
+		roundTripper,
+		d.Get("max_requests_per_second").(float64),
+		d.Get("max_concurrent_requests").(int),
+	)
+	roundTripper = newRetryTransport(
+		roundTripper,
+		d.Get("max_retries").(int),
+		time.Duration(d.Get("max_retry_wait").(int))*time.Second,
+	)
+
+	closeOnProviderStop(ctx, transport)
+
+	return &http.Client{Transport: roundTripper}, nil
+}
+
+// closeOnProviderStop releases the pooled connections of transport once
+// Terraform stops the provider. Nothing is registered for the plugin
+// shutdown: the connections go away with the process, and a hook would keep
+// every transport built by a reconfigured provider alive until then.
+func closeOnProviderStop(ctx context.Context, transport *http.Transport) {
+	stopCtx, ok := ctx.Value(schema.StopContextKey).(context.Context)
+	if !ok {
+		return
+	}
+	go func() {
+		<-stopCtx.Done()
+		log.Printf("[DEBUG] provider stopped, closing idle AWX API connections")
+		transport.CloseIdleConnections()
+	}()
+}
+
+// timeoutTransport bounds every single attempt of an API call, the response
+// body included, to timeout.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		err = t.timeoutError(req.Context(), ctx, err)
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel, timeoutError: func(err error) error {
+		return t.timeoutError(req.Context(), ctx, err)
+	}}
+	return resp, nil
+}
+
+// timeoutError tells apart err caused by request_timeout from a cancellation
+// of the caller.
+func (t *timeoutTransport) timeoutError(parent, ctx context.Context, err error) error {
+	if parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("no answer from AWX within the request_timeout of %s: %w", t.timeout, err)
+	}
+	return err
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel       context.CancelFunc
+	timeoutError func(error) error
+}
+
+func (b *cancelOnCloseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = b.timeoutError(err)
+	}
+	return n, err
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// proxyFunc returns the proxy selection of the AWX API transport. Without
+// proxy_url, the process wide HTTP_PROXY and HTTPS_PROXY variables apply as
+// for any Go program. no_proxy replaces the NO_PROXY variable in both cases.
+func proxyFunc(proxyURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	config := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		if _, err := url.Parse(proxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		config.HTTPProxy = proxyURL
+		config.HTTPSProxy = proxyURL
+		config.NoProxy = noProxy
+	} else if noProxy != "" {
+		config.NoProxy = noProxy
+	}
+	selectProxy := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return selectProxy(req.URL)
+	}, nil
+}
@@ -0,0 +1,120 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	oauth2GrantTypePassword          = "password"
+	oauth2GrantTypeClientCredentials = "client_credentials"
+
+	oauth2RevokeTimeout = 2 * time.Second
+)
+
+// oauth2Credentials holds what is exchanged for a token at `/api/o/token/`.
+type oauth2Credentials struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+	Scope        string
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// oauth2BasePath returns the OAuth2 endpoints path matching the controller
+// API base path: `/api/o/` for `/api/v2/`, `/api/controller/o/` behind the
+// platform gateway.
+func oauth2BasePath(apiBasePath string) string {
+	return strings.TrimSuffix(apiBasePath, "v2/") + "o/"
+}
+
+// exchangeOAuth2Token mints an access token for the given credentials.
+func exchangeOAuth2Token(ctx context.Context, client *http.Client, hostname, apiBasePath string, creds oauth2Credentials) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", creds.GrantType)
+	form.Set("scope", creds.Scope)
+	if creds.GrantType == oauth2GrantTypePassword {
+		form.Set("username", creds.Username)
+		form.Set("password", creds.Password)
+	}
+
+	resp, err := postOAuth2Form(ctx, client, hostname+oauth2BasePath(apiBasePath)+"token/", creds, form)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var body map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return "", fmt.Errorf("token endpoint responded with %d: %v", resp.StatusCode, body)
+	}
+
+	token := new(oauth2TokenResponse)
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned no access_token")
+	}
+	log.Printf("[DEBUG] obtained an OAuth2 token with scope %q expiring in %d seconds", token.Scope, token.ExpiresIn)
+	return token.AccessToken, nil
+}
+
+// revokeOAuth2Token invalidates a token minted by exchangeOAuth2Token.
+func revokeOAuth2Token(ctx context.Context, client *http.Client, hostname, apiBasePath string, creds oauth2Credentials, token string) error {
+	form := url.Values{}
+	form.Set("token", token)
+
+	resp, err := postOAuth2Form(ctx, client, hostname+oauth2BasePath(apiBasePath)+"revoke_token/", creds, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("revoke endpoint responded with %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func postOAuth2Form(ctx context.Context, client *http.Client, endpoint string, creds oauth2Credentials, form url.Values) (*http.Response, error) {
+	if creds.ClientSecret == "" {
+		// public applications identify themselves in the form
+		form.Set("client_id", creds.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if creds.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(creds.ClientID), url.QueryEscape(creds.ClientSecret))
+	}
+	return client.Do(req)
+}
+
+// revokeOAuth2TokenOnShutdown revokes token when the provider shuts down.
+func revokeOAuth2TokenOnShutdown(client *http.Client, hostname, apiBasePath string, creds oauth2Credentials, token string) {
+	registerShutdownHook(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), oauth2RevokeTimeout)
+		defer cancel()
+		if err := revokeOAuth2Token(ctx, client, hostname, apiBasePath, creds, token); err != nil {
+			log.Printf("[WARN] unable to revoke the OAuth2 token: %s", err)
+		}
+	})
+}
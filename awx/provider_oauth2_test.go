@@ -0,0 +1,206 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// oauth2Server is an AWX OAuth2 provider accepting the client
+// terraform:secret and the user admin:password.
+type oauth2Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	forms   map[string]url.Values
+	revoked []string
+}
+
+func newOAuth2Server(t *testing.T) *oauth2Server {
+	t.Helper()
+	s := &oauth2Server{forms: map[string]url.Values{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *oauth2Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.forms[r.URL.Path] = r.PostForm
+	s.mu.Unlock()
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+	}
+	if clientID != "terraform" || (ok && clientSecret != "secret") {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/o/token/":
+		if r.PostForm.Get("grant_type") == oauth2GrantTypePassword &&
+			(r.PostForm.Get("username") != "admin" || r.PostForm.Get("password") != "password") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(oauth2TokenResponse{
+			AccessToken: "minted",
+			TokenType:   "Bearer",
+			ExpiresIn:   3600,
+			Scope:       r.PostForm.Get("scope"),
+		})
+	case "/api/o/revoke_token/":
+		s.mu.Lock()
+		s.revoked = append(s.revoked, r.PostForm.Get("token"))
+		s.mu.Unlock()
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestExchangeOAuth2Token(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		creds oauth2Credentials
+		err   bool
+	}{
+		{
+			name:  "password grant of a confidential application",
+			creds: oauth2Credentials{GrantType: oauth2GrantTypePassword, ClientID: "terraform", ClientSecret: "secret", Username: "admin", Password: "password", Scope: "write"},
+		},
+		{
+			name:  "password grant of a public application",
+			creds: oauth2Credentials{GrantType: oauth2GrantTypePassword, ClientID: "terraform", Username: "admin", Password: "password", Scope: "read"},
+		},
+		{
+			name:  "client credentials grant",
+			creds: oauth2Credentials{GrantType: oauth2GrantTypeClientCredentials, ClientID: "terraform", ClientSecret: "secret", Scope: "write"},
+		},
+		{
+			name:  "rejected client secret",
+			creds: oauth2Credentials{GrantType: oauth2GrantTypeClientCredentials, ClientID: "terraform", ClientSecret: "wrong", Scope: "write"},
+			err:   true,
+		},
+		{
+			name:  "unknown client",
+			creds: oauth2Credentials{GrantType: oauth2GrantTypePassword, ClientID: "other", Username: "admin", Password: "password", Scope: "write"},
+			err:   true,
+		},
+		{
+			name:  "rejected password",
+			creds: oauth2Credentials{GrantType: oauth2GrantTypePassword, ClientID: "terraform", Username: "admin", Password: "wrong", Scope: "write"},
+			err:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newOAuth2Server(t)
+			token, err := exchangeOAuth2Token(context.Background(), server.Client(), server.URL, "/api/v2/", tc.creds)
+			if tc.err {
+				if err == nil {
+					t.Errorf("exchanged for %q, expected an error", token)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != "minted" {
+				t.Errorf("exchanged for %q, expected minted", token)
+			}
+
+			form := server.forms["/api/o/token/"]
+			if form.Get("grant_type") != tc.creds.GrantType || form.Get("scope") != tc.creds.Scope {
+				t.Errorf("sent %v", form)
+			}
+			if tc.creds.GrantType == oauth2GrantTypeClientCredentials && form.Has("username") {
+				t.Errorf("client credentials grant sent the username: %v", form)
+			}
+		})
+	}
+}
+
+func TestExchangeOAuth2TokenGatewayPath(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewEncoder(w).Encode(oauth2TokenResponse{AccessToken: "minted"})
+	}))
+	defer server.Close()
+
+	creds := oauth2Credentials{GrantType: oauth2GrantTypeClientCredentials, ClientID: "terraform", ClientSecret: "secret"}
+	if _, err := exchangeOAuth2Token(context.Background(), server.Client(), server.URL, "/api/controller/v2/", creds); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/api/controller/o/token/" {
+		t.Errorf("exchanged at %s", path)
+	}
+}
+
+func TestExchangeOAuth2TokenInvalidResponse(t *testing.T) {
+	for name, body := range map[string]string{
+		"not JSON":        "<html>maintenance</html>",
+		"no access token": `{"token_type": "Bearer"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			creds := oauth2Credentials{GrantType: oauth2GrantTypeClientCredentials, ClientID: "terraform", ClientSecret: "secret"}
+			if token, err := exchangeOAuth2Token(context.Background(), server.Client(), server.URL, "/api/v2/", creds); err == nil {
+				t.Errorf("exchanged for %q, expected an error", token)
+			}
+		})
+	}
+}
+
+func TestRevokeOAuth2Token(t *testing.T) {
+	server := newOAuth2Server(t)
+	creds := oauth2Credentials{GrantType: oauth2GrantTypePassword, ClientID: "terraform", ClientSecret: "secret"}
+
+	if err := revokeOAuth2Token(context.Background(), server.Client(), server.URL, "/api/v2/", creds, "minted"); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.revoked) != 1 || server.revoked[0] != "minted" {
+		t.Errorf("revoked %v", server.revoked)
+	}
+
+	creds.ClientSecret = "wrong"
+	if err := revokeOAuth2Token(context.Background(), server.Client(), server.URL, "/api/v2/", creds, "minted"); err == nil {
+		t.Error("revoked with a rejected client secret")
+	}
+}
+
+func TestRevokeOAuth2TokenOnShutdown(t *testing.T) {
+	server := newOAuth2Server(t)
+	creds := oauth2Credentials{GrantType: oauth2GrantTypePassword, ClientID: "terraform"}
+	Shutdown()
+
+	revokeOAuth2TokenOnShutdown(server.Client(), server.URL, "/api/v2/", creds, "minted")
+	if len(server.revoked) != 0 {
+		t.Fatalf("revoked %v before the shutdown", server.revoked)
+	}
+	if n := registeredShutdownHooks(); n != 1 {
+		t.Errorf("%d shutdown hooks registered for a token", n)
+	}
+	Shutdown()
+	if len(server.revoked) != 1 || server.revoked[0] != "minted" {
+		t.Errorf("revoked %v at shutdown", server.revoked)
+	}
+	if form := server.forms["/api/o/revoke_token/"]; form.Get("client_id") != "terraform" {
+		t.Errorf("public application revoked with %v", form)
+	}
+}
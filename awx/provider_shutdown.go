@@ -0,0 +1,32 @@
+package awx
+
+import (
+	"sync"
+)
+
+var shutdownHooks struct {
+	sync.Mutex
+	hooks []func()
+}
+
+// registerShutdownHook adds hook to the cleanup run by Shutdown.
+func registerShutdownHook(hook func()) {
+	shutdownHooks.Lock()
+	defer shutdownHooks.Unlock()
+	shutdownHooks.hooks = append(shutdownHooks.hooks, hook)
+}
+
+// Shutdown releases what the configured provider instances hold past the
+// process, such as the tokens minted at configure time. Hooks run in
+// the reverse order of their registration. It is meant to be called once the
+// plugin server has stopped.
+func Shutdown() {
+	shutdownHooks.Lock()
+	hooks := shutdownHooks.hooks
+	shutdownHooks.hooks = nil
+	shutdownHooks.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
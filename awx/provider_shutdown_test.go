@@ -0,0 +1,60 @@
+package awx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// registeredShutdownHooks counts the hooks Shutdown would run.
+func registeredShutdownHooks() int {
+	shutdownHooks.Lock()
+	defer shutdownHooks.Unlock()
+	return len(shutdownHooks.hooks)
+}
+
+func TestShutdown(t *testing.T) {
+	Shutdown()
+	var order []int
+	for i := 0; i < 3; i++ {
+		i := i
+		registerShutdownHook(func() { order = append(order, i) })
+	}
+
+	Shutdown()
+	if len(order) != 3 || order[0] != 2 || order[1] != 1 || order[2] != 0 {
+		t.Errorf("hooks run in the order %v, expected [2 1 0]", order)
+	}
+	if n := registeredShutdownHooks(); n != 0 {
+		t.Errorf("%d shutdown hooks left after the shutdown", n)
+	}
+	Shutdown()
+	if len(order) != 3 {
+		t.Errorf("hooks run again by a second shutdown: %v", order)
+	}
+}
+
+// TestProviderConfigureShutdownHooks configures the provider repeatedly, as
+// the test framework does, and checks nothing piles up for the shutdown.
+func TestProviderConfigureShutdownHooks(t *testing.T) {
+	server := newTestServer(t)
+	Shutdown()
+
+	for i := 0; i < 5; i++ {
+		stopCtx, stop := context.WithCancel(context.Background())
+		ctx := context.WithValue(context.Background(), schema.StopContextKey, stopCtx)
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+			"hostname":    server.URL,
+			"token":       "test",
+			"max_retries": 0,
+		})
+		if _, diags := providerConfigure(ctx, d); diags.HasError() {
+			t.Fatalf("configure failed: %v", diags)
+		}
+		stop()
+	}
+	if n := registeredShutdownHooks(); n != 0 {
+		t.Errorf("%d shutdown hooks registered by 5 configured providers without OAuth2 token", n)
+	}
+}
@@ -0,0 +1,154 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/denouche/terraform-provider-awx/awx/internal/fakeawx"
+)
+
+// testProtoV5ProviderFactories serve the provider the way main does, with
+// the SDKv2 and plugin framework resources behind the mux server.
+var testProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"awx": func() (tfprotov5.ProviderServer, error) {
+		providerServer, err := NewProviderServer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return providerServer(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// newTestServer starts a fake AWX, stopped when the test ends.
+func newTestServer(t *testing.T) *fakeawx.Server {
+	t.Helper()
+	server := fakeawx.New()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testProviderConfig is the provider block reaching server, followed by
+// the configuration of the test.
+func testProviderConfig(server *fakeawx.Server, config string) string {
+	return fmt.Sprintf(`
+provider "awx" {
+  hostname    = %q
+  token       = "test"
+  max_retries = 0
+}
+%s`, server.URL, config)
+}
+
+// testObjectID returns the ID of the object of collection whose field has
+// value, failing the test when there is none.
+func testObjectID(t *testing.T, server *fakeawx.Server, collection, field string, value interface{}) int {
+	t.Helper()
+	for _, obj := range server.List(collection) {
+		if fmt.Sprint(obj[field]) == fmt.Sprint(value) {
+			return obj["id"].(int)
+		}
+	}
+	t.Fatalf("no %s with %s %v", collection, field, value)
+	return 0
+}
+
+// testUpdateObject changes an object outside of Terraform, to check the drift
+// is detected.
+func testUpdateObject(t *testing.T, server *fakeawx.Server, collection, field string, value interface{}, fields map[string]interface{}) func() {
+	return func() {
+		server.Update(collection, testObjectID(t, server, collection, field, value), fields)
+	}
+}
+
+// testDeleteObject deletes an object outside of Terraform.
+func testDeleteObject(t *testing.T, server *fakeawx.Server, collection, field string, value interface{}) func() {
+	return func() {
+		server.Delete(collection, testObjectID(t, server, collection, field, value))
+	}
+}
+
+// testCheckExists checks the object of a resource exists in collection.
+func testCheckExists(server *fakeawx.Server, collection, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", name)
+		}
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("%s has a non numeric ID %q", name, rs.Primary.ID)
+		}
+		if server.Get(collection, id) == nil {
+			return fmt.Errorf("%s %d of %s does not exist", collection, id, name)
+		}
+		return nil
+	}
+}
+
+// testCheckDestroyed checks the objects of the resources of resourceType
+// were deleted from collection.
+func testCheckDestroyed(server *fakeawx.Server, collection, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return fmt.Errorf("%s has a non numeric ID %q", rs.Type, rs.Primary.ID)
+			}
+			if server.Get(collection, id) != nil {
+				return fmt.Errorf("%s %d still exists", collection, id)
+			}
+		}
+		return nil
+	}
+}
+
+// testCheckField checks a field of the object of a resource as AWX stores it.
+func testCheckField(server *fakeawx.Server, collection, name, field string, expected interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", name)
+		}
+		id, _ := strconv.Atoi(rs.Primary.ID)
+		obj := server.Get(collection, id)
+		if obj == nil {
+			return fmt.Errorf("%s %d of %s does not exist", collection, id, name)
+		}
+		if fmt.Sprint(obj[field]) != fmt.Sprint(expected) {
+			return fmt.Errorf("%s of %s %d is %v, expected %v", field, collection, id, obj[field], expected)
+		}
+		return nil
+	}
+}
+
+// testCheckAssociated checks the object of a resource is associated to
+// exactly the given objects through one of its related endpoints.
+func testCheckAssociated(server *fakeawx.Server, collection, name, related string, expected ...int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", name)
+		}
+		id, _ := strconv.Atoi(rs.Primary.ID)
+		associated := server.Associated(collection, id, related)
+		if fmt.Sprint(associated) != fmt.Sprint(expected) {
+			return fmt.Errorf("%s of %s %d are %v, expected %v", related, collection, id, associated, expected)
+		}
+		return nil
+	}
+}
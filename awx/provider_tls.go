@@ -0,0 +1,85 @@
+package awx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// buildTLSConfig builds the TLS configuration of the AWX API transport from
+// the provider settings. It returns nil when the defaults of the Go runtime
+// can be kept.
+func buildTLSConfig(d *schema.ResourceData, insecure bool) (*tls.Config, error) {
+	caCertFile := d.Get("ca_cert_file").(string)
+	caCertPEM := d.Get("ca_cert_pem").(string)
+	clientCert := d.Get("client_cert").(string)
+	clientKey := d.Get("client_key").(string)
+	serverName := d.Get("tls_server_name").(string)
+
+	if !insecure && caCertFile == "" && caCertPEM == "" && clientCert == "" && clientKey == "" && serverName == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecure,
+		ServerName:         serverName,
+	}
+
+	if caCertFile != "" || caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if caCertFile != "" {
+			content, err := os.ReadFile(caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read ca_cert_file %s: %w", caCertFile, err)
+			}
+			if !pool.AppendCertsFromPEM(content) {
+				return nil, fmt.Errorf("no PEM encoded certificate found in ca_cert_file %s", caCertFile)
+			}
+		}
+		if caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in ca_cert_pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be set together")
+		}
+		certPEM, err := readPEMOrFile("client_cert", clientCert)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM, err := readPEMOrFile("client_key", clientKey)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEMOrFile returns value when it holds PEM data, the content of the file
+// it points to otherwise.
+func readPEMOrFile(attribute, value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	content, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s %s: %w", attribute, value, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,187 @@
+package awx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testClientCertificate generates a self-signed client certificate, returned
+// PEM encoded with its key.
+func testClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// writeTestFile writes content in a temporary directory and returns its path.
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// testTLSGet sends a request to server with the TLS configuration built from
+// config.
+func testTLSGet(t *testing.T, server *httptest.Server, config map[string]interface{}, insecure bool) error {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+	tlsConfig, err := buildTLSConfig(d, insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+	defer transport.CloseIdleConnections()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestBuildTLSConfigServerCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	caFile := writeTestFile(t, "ca.pem", caPEM)
+
+	for _, tc := range []struct {
+		name     string
+		config   map[string]interface{}
+		insecure bool
+		ok       bool
+	}{
+		{"system trust store", map[string]interface{}{}, false, false},
+		{"insecure", map[string]interface{}{}, true, true},
+		{"ca_cert_pem", map[string]interface{}{"ca_cert_pem": caPEM}, false, true},
+		{"ca_cert_file", map[string]interface{}{"ca_cert_file": caFile}, false, true},
+		// the httptest certificate is issued for example.com
+		{"tls_server_name", map[string]interface{}{"ca_cert_pem": caPEM, "tls_server_name": "example.com"}, false, true},
+		{"mismatching tls_server_name", map[string]interface{}{"ca_cert_pem": caPEM, "tls_server_name": "awx.invalid"}, false, false},
+		{"insecure with a mismatching tls_server_name", map[string]interface{}{"tls_server_name": "awx.invalid"}, true, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := testTLSGet(t, server, tc.config, tc.insecure)
+			if tc.ok && err != nil {
+				t.Errorf("request failed: %s", err)
+			}
+			if !tc.ok && err == nil {
+				t.Error("request succeeded, expected a certificate error")
+			}
+		})
+	}
+}
+
+func TestBuildTLSConfigClientCertificate(t *testing.T) {
+	certPEM, keyPEM := testClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(certPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	for _, tc := range []struct {
+		name   string
+		config map[string]interface{}
+		ok     bool
+	}{
+		{"no client certificate", map[string]interface{}{"ca_cert_pem": caPEM}, false},
+		{"PEM encoded", map[string]interface{}{"ca_cert_pem": caPEM, "client_cert": certPEM, "client_key": keyPEM}, true},
+		{
+			"files",
+			map[string]interface{}{
+				"ca_cert_pem": caPEM,
+				"client_cert": writeTestFile(t, "client.crt", certPEM),
+				"client_key":  writeTestFile(t, "client.key", keyPEM),
+			},
+			true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := testTLSGet(t, server, tc.config, false)
+			if tc.ok && err != nil {
+				t.Errorf("request failed: %s", err)
+			}
+			if !tc.ok && err == nil {
+				t.Error("request succeeded without a client certificate")
+			}
+		})
+	}
+}
+
+func TestBuildTLSConfigInvalid(t *testing.T) {
+	certPEM, keyPEM := testClientCertificate(t)
+	_, otherKeyPEM := testClientCertificate(t)
+	badPEM := "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n"
+
+	for _, tc := range []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{"bad ca_cert_pem", map[string]interface{}{"ca_cert_pem": badPEM}},
+		{"ca_cert_pem without PEM", map[string]interface{}{"ca_cert_pem": "not a certificate"}},
+		{"bad ca_cert_file", map[string]interface{}{"ca_cert_file": writeTestFile(t, "ca.pem", "not a certificate")}},
+		{"missing ca_cert_file", map[string]interface{}{"ca_cert_file": filepath.Join(t.TempDir(), "missing.pem")}},
+		{"client_cert without client_key", map[string]interface{}{"client_cert": certPEM}},
+		{"client_key without client_cert", map[string]interface{}{"client_key": keyPEM}},
+		{"bad client_cert", map[string]interface{}{"client_cert": badPEM, "client_key": keyPEM}},
+		{"mismatching client_key", map[string]interface{}{"client_cert": certPEM, "client_key": otherKeyPEM}},
+		{"missing client_cert file", map[string]interface{}{"client_cert": filepath.Join(t.TempDir(), "missing.crt"), "client_key": keyPEM}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.config)
+			if tlsConfig, err := buildTLSConfig(d, false); err == nil {
+				t.Errorf("built %+v, expected an error", tlsConfig)
+			}
+		})
+	}
+}
+
+func TestBuildTLSConfigDefaults(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	if tlsConfig, err := buildTLSConfig(d, false); tlsConfig != nil || err != nil {
+		t.Errorf("built %+v, %v without TLS settings, expected the Go defaults", tlsConfig, err)
+	}
+}
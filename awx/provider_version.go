@@ -0,0 +1,208 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	pingAPIEndpoint   = "/api/v2/ping/"
+	configAPIEndpoint = "/api/v2/config/"
+
+	// AWX releases are numbered from 1.0.0 up while Tower and the AAP
+	// controller are numbered 3.x and 4.x, below this major version.
+	firstAWXMajorVersion = 9
+)
+
+var leadingVersionRegexp = regexp.MustCompile(`^v?\d+(\.\d+)*`)
+
+// controllerInfo is what the provider learns about the controller at
+// configure time, from `/api/v2/ping/` and `/api/v2/config/`.
+type controllerInfo struct {
+	Version     string                 `json:"version"`
+	InstallUUID string                 `json:"install_uuid"`
+	ActiveNode  string                 `json:"active_node"`
+	HA          bool                   `json:"ha"`
+	LicenseInfo map[string]interface{} `json:"license_info"`
+
+	parsedVersion *version.Version
+}
+
+// fetchControllerInfo reads the version of the controller. The license info
+// is only readable by authenticated users and is left empty when the call fails.
+func fetchControllerInfo(c *awxClient) (*controllerInfo, error) {
+	info := new(controllerInfo)
+	resp, err := c.requester.GetJSON(pingAPIEndpoint, info, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("%s responded with %d", pingAPIEndpoint, resp.StatusCode)
+	}
+
+	config := new(controllerInfo)
+	resp, err = c.requester.GetJSON(configAPIEndpoint, config, map[string]string{})
+	if err == nil && resp.StatusCode == 200 {
+		info.LicenseInfo = config.LicenseInfo
+		if info.Version == "" {
+			info.Version = config.Version
+		}
+	}
+
+	if v := leadingVersionRegexp.FindString(info.Version); v != "" {
+		info.parsedVersion, _ = version.NewVersion(v)
+	}
+	return info, nil
+}
+
+// isAWX reports whether the version follows the AWX numbering rather than the
+// Tower and AAP controller one.
+func (i *controllerInfo) isAWX() bool {
+	return i.parsedVersion != nil && i.parsedVersion.Segments()[0] >= firstAWXMajorVersion
+}
+
+// versionedAttribute describes an attribute only supported by some controller
+// versions. Bounds are given for both numbering schemes, the lower one being
+// the first supporting version and the upper one the first version ignoring
+// the attribute. Empty bounds are open.
+type versionedAttribute struct {
+	Attribute        string
+	AWXSince         string
+	AWXUntil         string
+	ControllerSince  string
+	ControllerUntil  string
+	ErrorWhenMissing bool
+}
+
+var (
+	attributeExecutionEnvironment = versionedAttribute{
+		Attribute:        "execution_environment",
+		AWXSince:         "18.0.0",
+		ControllerSince:  "4.0.0",
+		ErrorWhenMissing: true,
+	}
+	attributeCustomVirtualenv = versionedAttribute{
+		Attribute:       "custom_virtualenv",
+		AWXUntil:        "18.0.0",
+		ControllerUntil: "4.0.0",
+	}
+	attributeDefaultEnvironment = versionedAttribute{
+		Attribute:        "default_environment",
+		AWXSince:         "18.0.0",
+		ControllerSince:  "4.0.0",
+		ErrorWhenMissing: true,
+	}
+	attributePreventInstanceGroupFallback = versionedAttribute{
+		Attribute:        "prevent_instance_group_fallback",
+		AWXSince:         "21.5.0",
+		ControllerSince:  "4.3.0",
+		ErrorWhenMissing: true,
+	}
+)
+
+// launchPromptAttributes are the prompts on launch of job templates added with
+// the prompts for labels and instance groups.
+var launchPromptAttributes = []versionedAttribute{
+	{Attribute: "ask_execution_environment_on_launch", AWXSince: "21.11.0", ControllerSince: "4.4.0", ErrorWhenMissing: true},
+	{Attribute: "ask_labels_on_launch", AWXSince: "21.11.0", ControllerSince: "4.4.0", ErrorWhenMissing: true},
+	{Attribute: "ask_forks_on_launch", AWXSince: "21.11.0", ControllerSince: "4.4.0", ErrorWhenMissing: true},
+	{Attribute: "ask_job_slice_count_on_launch", AWXSince: "21.11.0", ControllerSince: "4.4.0", ErrorWhenMissing: true},
+	{Attribute: "ask_timeout_on_launch", AWXSince: "21.11.0", ControllerSince: "4.4.0", ErrorWhenMissing: true},
+	{Attribute: "ask_instance_groups_on_launch", AWXSince: "21.11.0", ControllerSince: "4.4.0", ErrorWhenMissing: true},
+}
+
+// obsoleteInventorySourceAttributes were dropped with the move to inventory plugins.
+var obsoleteInventorySourceAttributes = []versionedAttribute{
+	{Attribute: "source_regions", AWXUntil: "14.0.0", ControllerUntil: "3.8.0"},
+	{Attribute: "instance_filters", AWXUntil: "14.0.0", ControllerUntil: "3.8.0"},
+	{Attribute: "group_by", AWXUntil: "14.0.0", ControllerUntil: "3.8.0"},
+}
+
+// supports reports whether the attribute is supported by the controller, and
+// whether that could be determined at all.
+func (a versionedAttribute) supports(info *controllerInfo) (supported bool, known bool) {
+	if info == nil || info.parsedVersion == nil {
+		return true, false
+	}
+	since, until := a.ControllerSince, a.ControllerUntil
+	if info.isAWX() {
+		since, until = a.AWXSince, a.AWXUntil
+	}
+	if since != "" && info.parsedVersion.LessThan(version.Must(version.NewVersion(since))) {
+		return false, true
+	}
+	if until != "" && !info.parsedVersion.LessThan(version.Must(version.NewVersion(until))) {
+		return false, true
+	}
+	return true, true
+}
+
+// checkVersionedAttributes returns a diagnostic for every attribute set in
+// the configuration but not supported by the connected controller: an error
+// when the controller would reject it, a warning when it would be ignored.
+func checkVersionedAttributes(d *schema.ResourceData, m interface{}, resourceName string, attributes ...versionedAttribute) diag.Diagnostics {
+	isSet := func(attribute string) bool {
+		_, ok := d.GetOk(attribute)
+		return ok
+	}
+	return checkVersionedAttributesSet(m.(*awxClient).controller, isSet, resourceName, attributes...)
+}
+
+// checkVersionedAttributesSet is checkVersionedAttributes for the resources
+// not built on a ResourceData, isSet telling whether an attribute is set.
+func checkVersionedAttributesSet(info *controllerInfo, isSet func(attribute string) bool, resourceName string, attributes ...versionedAttribute) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, a := range attributes {
+		if !isSet(a.Attribute) {
+			continue
+		}
+		supported, known := a.supports(info)
+		if !known || supported {
+			continue
+		}
+
+		severity := diag.Warning
+		detail := "The attribute is ignored by AWX %s, remove it from the %s configuration."
+		if a.ErrorWhenMissing {
+			severity = diag.Error
+			detail = "The attribute is not supported by AWX %s, remove it from the %s configuration or upgrade the controller."
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      severity,
+			Summary:       fmt.Sprintf("%s is not supported by the connected controller", a.Attribute),
+			Detail:        fmt.Sprintf(detail, info.Version, resourceName),
+			AttributePath: cty.GetAttrPath(a.Attribute),
+		})
+	}
+	return diags
+}
+
+// customizeDiffVersionedAttributes fails the plan when an attribute the
+// connected controller would reject is set. The warnings about the ignored
+// attributes are left to checkVersionedAttributes on apply, a CustomizeDiff
+// having no way to report them.
+func customizeDiffVersionedAttributes(resourceName string, attributes ...versionedAttribute) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := m.(*awxClient)
+		if !ok {
+			return nil
+		}
+		isSet := func(attribute string) bool {
+			_, ok := d.GetOk(attribute)
+			return ok
+		}
+		for _, diagnostic := range checkVersionedAttributesSet(client.controller, isSet, resourceName, attributes...) {
+			if diagnostic.Severity == diag.Error {
+				return fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+		}
+		return nil
+	}
+}
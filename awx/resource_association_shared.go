@@ -0,0 +1,113 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// compositeIDSeparator joins the IDs of the objects an association resource
+// links, e.g. `<job_template_id>:<credential_id>`.
+const compositeIDSeparator = ":"
+
+func buildCompositeID(ids ...int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, compositeIDSeparator)
+}
+
+func splitCompositeID(id string, attributes ...string) ([]string, error) {
+	parts := strings.Split(id, compositeIDSeparator)
+	if len(parts) != len(attributes) {
+		return nil, fmt.Errorf("unexpected ID %q, expected %s", id, strings.Join(attributes, compositeIDSeparator))
+	}
+	return parts, nil
+}
+
+// importStateCompositeID imports a resource identified by a composite ID,
+// setting each of attributes from the matching part of the ID. Parts are
+// numeric IDs or named URLs, e.g. `Deploy++Engineering:Vault`.
+func importStateCompositeID(attributes ...string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		parts, err := splitCompositeID(d.Id(), attributes...)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int, len(parts))
+		for i, attribute := range attributes {
+			ids[i], err = resolveIDOrNamedURL(m.(*awxClient), namedURLEndpoints[attribute], parts[i])
+			if err != nil {
+				return nil, err
+			}
+			d.Set(attribute, ids[i])
+		}
+		d.SetId(buildCompositeID(ids...))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// compositeIDStateUpgrader rewrites the ID association resources were given
+// up to schema version 0, the ID of one of the linked objects, to the
+// composite ID built from attributes.
+func compositeIDStateUpgrader(attributes ...string) schema.StateUpgrader {
+	attributeTypes := map[string]cty.Type{"id": cty.String}
+	for _, attribute := range attributes {
+		attributeTypes[attribute] = cty.Number
+	}
+	return schema.StateUpgrader{
+		Version: 0,
+		Type:    cty.Object(attributeTypes),
+		Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			ids := make([]int, len(attributes))
+			for i, attribute := range attributes {
+				switch v := rawState[attribute].(type) {
+				case int:
+					ids[i] = v
+				case float64:
+					ids[i] = int(v)
+				default:
+					return nil, fmt.Errorf("unable to upgrade the state of %s: %s is %v", rawState["id"], attribute, v)
+				}
+			}
+			rawState["id"] = buildCompositeID(ids...)
+			return rawState, nil
+		},
+	}
+}
+
+// readAssociation checks the object set in childAttribute is still listed by
+// the related endpoint of its parent, e.g. `/api/v2/job_templates/<id>/credentials/`.
+// The resource is removed from the state when the association, or the parent,
+// was removed outside of Terraform.
+func readAssociation(d *schema.ResourceData, m interface{}, tfElement, endpoint, childAttribute string) diag.Diagnostics {
+	client := m.(*awxClient)
+	childID := d.Get(childAttribute).(int)
+
+	result := new(struct {
+		Count int `json:"count"`
+	})
+	err := client.getJSON(endpoint, result, map[string]string{"id": strconv.Itoa(childID)})
+	if err != nil {
+		if removeFromStateIfNotFound(d, tfElement, err) {
+			return nil
+		}
+		return buildDiagnosticsMessage(
+			fmt.Sprintf("Unable to fetch %s", tfElement),
+			"Unable to load %s %s: got %s",
+			tfElement, d.Id(), err.Error(),
+		)
+	}
+	if result.Count == 0 {
+		log.Printf("[WARN] %s %s not found, removing it from the state", tfElement, d.Id())
+		d.SetId("")
+	}
+	return nil
+}
@@ -46,15 +46,30 @@ func resourceCredential() *schema.Resource {
 				Description: "Specify the type of credential you want to create. Refer to the Ansible Tower documentation for details on each type",
 			},
 			"inputs": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: validateJSONObject,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("credentials"),
+		},
 	}
 }
 
 func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "credentials", map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"organization":    d.Get("organization_id").(int),
+		"credential_type": d.Get("credential_type_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceCredentialUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
 	var err error
 
@@ -79,15 +94,14 @@ func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m int
 		"inputs":          inputs_map,
 	}
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credential",
-			Detail:   fmt.Sprintf("Unable to create new credential: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new credential",
+			err,
+			"Unable to create new credential",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -99,10 +113,19 @@ func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m int
 func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	id, _ := strconv.Atoi(d.Id())
-	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	// goawx reads the credential type from credential_type_id, which the API
+	// does not return
+	cred := new(struct {
+		awx.Credential
+		CredentialType int `json:"credential_type"`
+	})
+	err := client.getJSON(fmt.Sprintf("/api/v2/credentials/%d/", id), cred, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Credential", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credential",
@@ -114,7 +137,8 @@ func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m inter
 	d.Set("name", cred.Name)
 	d.Set("description", cred.Description)
 	d.Set("organization_id", cred.OrganizationID)
-	d.Set("inputs", cred.Inputs)
+	d.Set("credential_type_id", cred.CredentialType)
+	setJSONOnImport(d, "inputs", cred.Inputs)
 
 	return diags
 }
@@ -154,15 +178,14 @@ func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m int
 			"inputs":          inputs_map,
 		}
 
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return append(diags, buildDiagAPIFail(
+				"Unable to update existing credentials",
+				err,
+				"Unable to update existing credentials with id %d", id,
+			)...)
 		}
 	}
 
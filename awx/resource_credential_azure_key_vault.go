@@ -15,7 +15,6 @@ import (
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -57,10 +56,24 @@ func resourceCredentialAzureKeyVault() *schema.Resource {
 				Required: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("credentials"),
+		},
 	}
 }
 
 func resourceCredentialAzureKeyVaultCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "credentials", map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"organization":    d.Get("organization_id").(int),
+		"credential_type": 19,
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceCredentialAzureKeyVaultUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
 	var err error
 
@@ -77,15 +90,14 @@ func resourceCredentialAzureKeyVaultCreate(ctx context.Context, d *schema.Resour
 		},
 	}
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new credentials",
+			err,
+			"Unable to create new credentials",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -97,10 +109,13 @@ func resourceCredentialAzureKeyVaultCreate(ctx context.Context, d *schema.Resour
 func resourceCredentialAzureKeyVaultRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Credential", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -149,15 +164,14 @@ func resourceCredentialAzureKeyVaultUpdate(ctx context.Context, d *schema.Resour
 			},
 		}
 
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return append(diags, buildDiagAPIFail(
+				"Unable to update existing credentials",
+				err,
+				"Unable to update existing credentials with id %d", id,
+			)...)
 		}
 	}
 
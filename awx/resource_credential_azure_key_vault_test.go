@@ -0,0 +1,66 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredentialAzureKeyVault(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential_azure_key_vault" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+  url             = "https://vault.example.com"
+  client          = "client"
+  secret          = "secret"
+  tenant          = "tenant"
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credentials", "awx_credential_azure_key_vault"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credentials", "awx_credential_azure_key_vault.test"),
+					resource.TestCheckResourceAttr("awx_credential_azure_key_vault.test", "url", "https://vault.example.com"),
+				),
+			},
+			{
+				ResourceName:            "awx_credential_azure_key_vault.test",
+				ImportState:             true,
+				ImportStateId:           "test++Microsoft Azure Key Vault+external++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credentials", "awx_credential_azure_key_vault.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credentials", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "credentials", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
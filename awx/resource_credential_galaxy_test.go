@@ -0,0 +1,71 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredentialGalaxy(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential_galaxy" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+  url             = "https://galaxy.ansible.com"
+  auth_url        = "https://sso.example.com/token"
+  token           = "token"
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credentials", "awx_credential_galaxy"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credentials", "awx_credential_galaxy.test"),
+					resource.TestCheckResourceAttr("awx_credential_galaxy.test", "url", "https://galaxy.ansible.com"),
+				),
+			},
+			{
+				ResourceName:            "awx_credential_galaxy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				ResourceName:            "awx_credential_galaxy.test",
+				ImportState:             true,
+				ImportStateId:           "test++Ansible Galaxy/Automation Hub API Token+galaxy++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credentials", "awx_credential_galaxy.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credentials", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "credentials", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
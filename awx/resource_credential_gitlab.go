@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -50,10 +49,24 @@ func resourceCredentialGitlab() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("credentials"),
+		},
 	}
 }
 
 func resourceCredentialGitlabCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "credentials", map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"organization":    d.Get("organization_id").(int),
+		"credential_type": 12,
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceCredentialGitlabUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
 	var err error
 
@@ -67,15 +80,14 @@ func resourceCredentialGitlabCreate(ctx context.Context, d *schema.ResourceData,
 		},
 	}
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new credentials",
+			err,
+			"Unable to create new credentials",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -87,10 +99,13 @@ func resourceCredentialGitlabCreate(ctx context.Context, d *schema.ResourceData,
 func resourceCredentialGitlabRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Credential", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -101,7 +116,7 @@ func resourceCredentialGitlabRead(ctx context.Context, d *schema.ResourceData, m
 
 	d.Set("name", cred.Name)
 	d.Set("description", cred.Description)
-	d.Set("token", cred.Inputs["token"])
+	setSecretInput(d, "token", cred.Inputs["token"])
 	d.Set("organization_id", cred.OrganizationID)
 
 	return diags
@@ -131,15 +146,14 @@ func resourceCredentialGitlabUpdate(ctx context.Context, d *schema.ResourceData,
 			},
 		}
 
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return append(diags, buildDiagAPIFail(
+				"Unable to update existing credentials",
+				err,
+				"Unable to update existing credentials with id %d", id,
+			)...)
 		}
 	}
 
@@ -0,0 +1,63 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredentialGitlab(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential_gitlab" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+  token           = "token"
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credentials", "awx_credential_gitlab"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credentials", "awx_credential_gitlab.test"),
+					resource.TestCheckResourceAttr("awx_credential_gitlab.test", "token", "token"),
+				),
+			},
+			{
+				ResourceName:            "awx_credential_gitlab.test",
+				ImportState:             true,
+				ImportStateId:           "test++GitLab Personal Access Token+token++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credentials", "awx_credential_gitlab.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credentials", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "credentials", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
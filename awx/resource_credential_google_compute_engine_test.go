@@ -0,0 +1,65 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredentialGoogleComputeEngine(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential_google_compute_engine" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+  username        = "svc@project.iam.gserviceaccount.com"
+  project         = "project"
+  ssh_key_data    = "key"
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credentials", "awx_credential_google_compute_engine"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credentials", "awx_credential_google_compute_engine.test"),
+					resource.TestCheckResourceAttr("awx_credential_google_compute_engine.test", "project", "project"),
+				),
+			},
+			{
+				ResourceName:            "awx_credential_google_compute_engine.test",
+				ImportState:             true,
+				ImportStateId:           "test++Google Compute Engine+cloud++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ssh_key_data"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credentials", "awx_credential_google_compute_engine.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credentials", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "credentials", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -15,7 +15,6 @@ import (
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -51,6 +50,9 @@ func resourceCredentialInputSource() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 	}
 }
 
@@ -66,15 +68,14 @@ func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.Resource
 		"metadata":          d.Get("metadata").(map[string]interface{}),
 	}
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	cred, err := client.CredentialInputSourceService.CreateCredentialInputSource(newSourceInput, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new credentials",
+			err,
+			"Unable to create new credentials",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -86,10 +87,13 @@ func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.Resource
 func resourceCredentialInputSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	id, _ := strconv.Atoi(d.Id())
 	inputSource, err := client.CredentialInputSourceService.GetCredentialInputSourceByID(id, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Credential Input Source", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -130,15 +134,14 @@ func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.Resource
 			"metadata":          d.Get("metadata").(map[string]interface{}),
 		}
 
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		_, err = client.CredentialInputSourceService.UpdateCredentialInputSourceByID(id, updatedSourceInput, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return append(diags, buildDiagAPIFail(
+				"Unable to update existing credentials",
+				err,
+				"Unable to update existing credentials with id %d", id,
+			)...)
 		}
 	}
 
@@ -149,7 +152,7 @@ func resourceCredentialInputSourceDelete(ctx context.Context, d *schema.Resource
 	var diags diag.Diagnostics
 
 	id, _ := strconv.Atoi(d.Id())
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	err := client.CredentialInputSourceService.DeleteCredentialInputSourceByID(id, map[string]string{})
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
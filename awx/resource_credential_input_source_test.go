@@ -0,0 +1,66 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredentialInputSource(t *testing.T) {
+	server := newTestServer(t)
+	sourceID := server.Add("credentials", map[string]interface{}{"name": "vault", "credential_type": 19})
+	targetID := server.Add("credentials", map[string]interface{}{"name": "machine", "credential_type": 1})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential_input_source" "test" {
+  description      = %q
+  input_field_name = "password"
+  target           = %d
+  source           = %d
+  metadata = {
+    secret_field = "password"
+  }
+}
+`, description, targetID, sourceID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credential_input_sources", "awx_credential_input_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credential_input_sources", "awx_credential_input_source.test"),
+					testCheckField(server, "credential_input_sources", "awx_credential_input_source.test", "source_credential", sourceID),
+					resource.TestCheckResourceAttr("awx_credential_input_source.test", "metadata.secret_field", "password"),
+				),
+			},
+			{
+				ResourceName:      "awx_credential_input_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credential_input_sources", "awx_credential_input_source.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credential_input_sources", "input_field_name", "password", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "credential_input_sources", "input_field_name", "password"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -15,7 +15,6 @@ import (
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -76,10 +75,24 @@ func resourceCredentialMachine() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("credentials"),
+		},
 	}
 }
 
 func resourceCredentialMachineCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "credentials", map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"organization":    d.Get("organization_id").(int),
+		"credential_type": 1,
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceCredentialMachineUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
 	var err error
 
@@ -100,15 +113,14 @@ func resourceCredentialMachineCreate(ctx context.Context, d *schema.ResourceData
 		},
 	}
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new credentials",
+			err,
+			"Unable to create new credentials",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -120,10 +132,13 @@ func resourceCredentialMachineCreate(ctx context.Context, d *schema.ResourceData
 func resourceCredentialMachineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Credential", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -135,13 +150,13 @@ func resourceCredentialMachineRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("name", cred.Name)
 	d.Set("description", cred.Description)
 	d.Set("username", cred.Inputs["username"])
-	d.Set("password", cred.Inputs["password"])
-	d.Set("ssh_key_data", cred.Inputs["ssh_key_data"])
+	setSecretInput(d, "password", cred.Inputs["password"])
+	setSecretInput(d, "ssh_key_data", cred.Inputs["ssh_key_data"])
 	d.Set("ssh_public_key_data", cred.Inputs["ssh_public_key_data"])
-	d.Set("ssh_key_unlock", cred.Inputs["ssh_key_unlock"])
+	setSecretInput(d, "ssh_key_unlock", cred.Inputs["ssh_key_unlock"])
 	d.Set("become_method", cred.Inputs["become_method"])
 	d.Set("become_username", cred.Inputs["become_username"])
-	d.Set("become_password", cred.Inputs["become_password"])
+	setSecretInput(d, "become_password", cred.Inputs["become_password"])
 	d.Set("organization_id", cred.OrganizationID)
 
 	return diags
@@ -187,15 +202,14 @@ func resourceCredentialMachineUpdate(ctx context.Context, d *schema.ResourceData
 			},
 		}
 
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return append(diags, buildDiagAPIFail(
+				"Unable to update existing credentials",
+				err,
+				"Unable to update existing credentials with id %d", id,
+			)...)
 		}
 	}
 
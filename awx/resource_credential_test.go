@@ -0,0 +1,55 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredential(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential" "test" {
+  name               = "test"
+  description        = %q
+  organization_id    = %d
+  credential_type_id = 5
+  inputs             = jsonencode({ username = "key", password = "secret" })
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credentials", "awx_credential"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credentials", "awx_credential.test"),
+					testCheckField(server, "credentials", "awx_credential.test", "inputs", map[string]interface{}{"username": "key", "password": "$encrypted$"}),
+				),
+			},
+			{
+				ResourceName:            "awx_credential.test",
+				ImportState:             true,
+				ImportStateId:           "test++Amazon Web Services+cloud++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"inputs"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credentials", "awx_credential.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credentials", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
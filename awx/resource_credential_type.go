@@ -16,7 +16,6 @@ import (
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -39,24 +38,40 @@ func resourceCredentialType() *schema.Resource {
 				Description: "Optional description of this credential type.",
 			},
 			"kind": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "cloud",
-				Description: "Choices cloud or net",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "cloud",
+				Description:      "Choices cloud or net",
+				ValidateDiagFunc: validateCredentialTypeKind,
 			},
 			"inputs": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateJSONObject,
 			},
 			"injectors": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateJSONObject,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("credential_types"),
+		},
 	}
 }
 
 func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "credential_types", map[string]interface{}{
+		"name": d.Get("name").(string),
+		"kind": d.Get("kind").(string),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceCredentialTypeUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
 	var err error
 
@@ -94,15 +109,14 @@ func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m
 		"injectors":   injectors_map,
 	}
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	credtype, err := client.CredentialTypeService.CreateCredentialType(newCredentialType, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credential type",
-			Detail:   fmt.Sprintf("Unable to create new credential type: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new credential type",
+			err,
+			"Unable to create new credential type",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(credtype.ID))
@@ -114,10 +128,13 @@ func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m
 func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	id, _ := strconv.Atoi(d.Id())
 	credtype, err := client.CredentialTypeService.GetCredentialTypeByID(id, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Credential Type", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credential type",
@@ -129,8 +146,8 @@ func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m i
 	d.Set("name", credtype.Name)
 	d.Set("description", credtype.Description)
 	d.Set("kind", credtype.Kind)
-	d.Set("inputs", credtype.Inputs)
-	d.Set("injectors", credtype.Injectors)
+	setJSONOnImport(d, "inputs", credtype.Inputs)
+	setJSONOnImport(d, "injectors", credtype.Injectors)
 
 	return diags
 }
@@ -182,15 +199,14 @@ func resourceCredentialTypeUpdate(ctx context.Context, d *schema.ResourceData, m
 			"injectors":   injectors_map,
 		}
 
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		_, err = client.CredentialTypeService.UpdateCredentialTypeByID(id, updatedCredentialType, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credential type",
-				Detail:   fmt.Sprintf("Unable to update existing credential type with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return append(diags, buildDiagAPIFail(
+				"Unable to update existing credential type",
+				err,
+				"Unable to update existing credential type with id %d", id,
+			)...)
 		}
 	}
 
@@ -0,0 +1,53 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceCredentialType(t *testing.T) {
+	server := newTestServer(t)
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_credential_type" "test" {
+  name        = "test"
+  description = %q
+  kind        = "cloud"
+  inputs      = jsonencode({ fields = [{ id = "token", type = "string", label = "Token", secret = true }] })
+  injectors   = jsonencode({ env = { TOKEN = "{{ token }}" } })
+}
+`, description))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "credential_types", "awx_credential_type"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "credential_types", "awx_credential_type.test"),
+					testCheckField(server, "credential_types", "awx_credential_type.test", "kind", "cloud"),
+				),
+			},
+			{
+				ResourceName:      "awx_credential_type.test",
+				ImportState:       true,
+				ImportStateId:     "test+cloud",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "credential_types", "awx_credential_type.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "credential_types", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
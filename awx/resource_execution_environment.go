@@ -45,39 +45,54 @@ func resourceExecutionEnvironment() *schema.Resource {
 				Default:  "",
 			},
 			"organization": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Optional: true,
-				Default:  "",
+				Default:  0,
 			},
 			"credential": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Optional: true,
-				Default:  "",
+				Default:  0,
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			numericIDStateUpgrader(0, "organization", "credential"),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("execution_environments"),
+		},
 	}
 }
 
 func resourceExecutionEnvironmentsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "execution_environments", map[string]interface{}{
+		"name": d.Get("name").(string),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceExecutionEnvironmentsUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ExecutionEnvironmentsService
 
 	result, err := awxService.CreateExecutionEnvironment(map[string]interface{}{
 		"name":         d.Get("name").(string),
 		"image":        d.Get("image").(string),
 		"description":  d.Get("description").(string),
-		"organization": AtoipOr(d.Get("organization").(string), nil),
-		"credential":   AtoipOr(d.Get("credential").(string), nil),
+		"organization": IntpOrNil(d.Get("organization").(int)),
+		"credential":   IntpOrNil(d.Get("credential").(int)),
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create ExecutionEnvironment %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create ExecutionEnvironments",
-			Detail:   fmt.Sprintf("ExecutionEnvironments with name, failed to create %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create ExecutionEnvironments",
+			err,
+			"ExecutionEnvironments with name %s, failed to create", d.Get("name").(string),
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -86,7 +101,7 @@ func resourceExecutionEnvironmentsCreate(ctx context.Context, d *schema.Resource
 
 func resourceExecutionEnvironmentsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ExecutionEnvironmentsService
 	id, diags := convertStateIDToNummeric("Update ExecutionEnvironments", d)
 	if diags.HasError() {
@@ -104,16 +119,15 @@ func resourceExecutionEnvironmentsUpdate(ctx context.Context, d *schema.Resource
 		"name":         d.Get("name").(string),
 		"image":        d.Get("image").(string),
 		"description":  d.Get("description").(string),
-		"organization": AtoipOr(d.Get("organization").(string), nil),
-		"credential":   AtoipOr(d.Get("credential").(string), nil),
+		"organization": IntpOrNil(d.Get("organization").(int)),
+		"credential":   IntpOrNil(d.Get("credential").(int)),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update ExecutionEnvironments",
-			Detail:   fmt.Sprintf("ExecutionEnvironments with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to update ExecutionEnvironments",
+			err,
+			"ExecutionEnvironments with name %s failed to update", d.Get("name").(string),
+		)...)
 	}
 
 	return resourceExecutionEnvironmentsRead(ctx, d, m)
@@ -121,7 +135,7 @@ func resourceExecutionEnvironmentsUpdate(ctx context.Context, d *schema.Resource
 
 func resourceExecutionEnvironmentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ExecutionEnvironmentsService
 	id, diags := convertStateIDToNummeric("Read ExecutionEnvironments", d)
 	if diags.HasError() {
@@ -130,6 +144,9 @@ func resourceExecutionEnvironmentsRead(ctx context.Context, d *schema.ResourceDa
 
 	res, err := awxService.GetExecutionEnvironmentByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, "ExecutionEnvironment", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("ExecutionEnvironment", id, err)
 
 	}
@@ -140,7 +157,7 @@ func resourceExecutionEnvironmentsRead(ctx context.Context, d *schema.ResourceDa
 func resourceExecutionEnvironmentsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	digMessagePart := "ExecutionEnvironment"
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ExecutionEnvironmentsService
 	id, diags := convertStateIDToNummeric("Delete ExecutionEnvironment", d)
 	if diags.HasError() {
@@ -0,0 +1,68 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceExecutionEnvironment(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_execution_environment" "test" {
+  name         = "test"
+  description  = %q
+  image        = "quay.io/ansible/awx-ee:latest"
+  organization = %d
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "execution_environments", "awx_execution_environment"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "execution_environments", "awx_execution_environment.test"),
+					testCheckField(server, "execution_environments", "awx_execution_environment.test", "organization", orgID),
+					resource.TestCheckResourceAttr("awx_execution_environment.test", "image", "quay.io/ansible/awx-ee:latest"),
+				),
+			},
+			{
+				ResourceName:      "awx_execution_environment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_execution_environment.test",
+				ImportState:       true,
+				ImportStateId:     "test",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "execution_environments", "awx_execution_environment.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "execution_environments", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "execution_environments", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -70,30 +70,61 @@ func resourceHost() *schema.Resource {
 				Default:  "",
 			},
 			"variables": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Default:   "",
-				StateFunc: normalizeJsonYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"variables"},
+				Description:   "Variables as a map, the values holding JSON as numbers or the result of jsonencode being decoded. Conflicts with variables.",
+			},
+			"variables_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          variablesModeReplace,
+				ValidateDiagFunc: validateVariablesMode,
+				Description:      "`replace` to manage all the variables, `merge` to only manage the declared keys and leave the others, as the ones inventory syncs or playbooks write.",
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("hosts"),
 		},
 	}
 }
 
 func resourceHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "hosts", map[string]interface{}{
+		"name":      d.Get("name").(string),
+		"inventory": d.Get("inventory_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceHostUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.HostService
 
+	variables, diags := variablesParam(d, client, "hosts", 0)
+	if diags.HasError() {
+		return diags
+	}
+
 	result, err := awxService.CreateHost(map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
 		"inventory":   d.Get("inventory_id").(int),
 		"enabled":     d.Get("enabled").(bool),
 		"instance_id": d.Get("instance_id").(string),
-		"variables":   d.Get("variables").(string),
+		"variables":   variables,
 	}, map[string]string{})
 	if err != nil {
 		return buildDiagCreateFail(diagElementHostTitle, err)
@@ -121,20 +152,24 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceHostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.HostService
 	id, diags := convertStateIDToNummeric(diagElementHostTitle, d)
 	if diags.HasError() {
 		return diags
 	}
 
+	variables, diags := variablesParam(d, client, "hosts", id)
+	if diags.HasError() {
+		return diags
+	}
 	_, err := awxService.UpdateHost(id, map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
 		"inventory":   d.Get("inventory_id").(int),
 		"enabled":     d.Get("enabled").(bool),
 		"instance_id": d.Get("instance_id").(string),
-		"variables":   d.Get("variables").(string),
+		"variables":   variables,
 	}, nil)
 	if err != nil {
 		return buildDiagUpdateFail(diagElementHostTitle, id, err)
@@ -161,7 +196,7 @@ func resourceHostUpdate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.HostService
 	id, diags := convertStateIDToNummeric(diagElementHostTitle, d)
 	if diags.HasError() {
@@ -169,6 +204,9 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 	res, err := awxService.GetHostByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, diagElementHostTitle, err) {
+			return nil
+		}
 		return buildDiagNotFoundFail(diagElementHostTitle, id, err)
 	}
 	d = setHostResourceData(d, res)
@@ -176,7 +214,7 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceHostDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.HostService
 	id, diags := convertStateIDToNummeric(diagElementHostTitle, d)
 	if diags.HasError() {
@@ -199,7 +237,7 @@ func setHostResourceData(d *schema.ResourceData, r *awx.Host) *schema.ResourceDa
 	d.Set("inventory_id", r.Inventory)
 	d.Set("enabled", r.Enabled)
 	d.Set("instance_id", r.InstanceID)
-	d.Set("variables", normalizeJsonYaml(r.Variables))
+	setVariablesOrMap(d, "variables", "variables_map", ownedVariables(d, r.Variables))
 	d.Set("group_ids", d.Get("group_ids").([]interface{}))
 	return d
 }
@@ -0,0 +1,192 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceHost(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	groupID := server.Add("groups", map[string]interface{}{"name": "group", "inventory": inventoryID})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_host" "test" {
+  name         = "test"
+  description  = %q
+  inventory_id = %d
+  enabled      = true
+  variables    = "foo: bar"
+  group_ids    = [%d]
+}
+`, description, inventoryID, groupID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "hosts", "awx_host"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "hosts", "awx_host.test"),
+					testCheckField(server, "hosts", "awx_host.test", "inventory", inventoryID),
+					testCheckAssociated(server, "hosts", "awx_host.test", "groups", groupID),
+				),
+			},
+			{
+				ResourceName:            "awx_host.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"group_ids"},
+			},
+			{
+				ResourceName:            "awx_host.test",
+				ImportState:             true,
+				ImportStateId:           "test++inventory++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"group_ids"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "hosts", "awx_host.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "hosts", "name", "test", map[string]interface{}{"enabled": false}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "hosts", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceHostVariablesMap(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	config := func(port int) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_host" "test" {
+  name         = "test"
+  inventory_id = %d
+
+  variables_map = {
+    ansible_host = "10.0.0.1"
+    ansible_port = %d
+    zip          = jsonencode("01234")
+    roles        = jsonencode(["web", "db"])
+  }
+}
+`, inventoryID, port))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "hosts", "awx_host"),
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(server, fmt.Sprintf(`
+resource "awx_host" "test" {
+  name          = "test"
+  inventory_id  = %d
+  variables     = "foo: bar"
+  variables_map = { foo = "bar" }
+}
+`, inventoryID)),
+				ExpectError: regexp.MustCompile(`conflicts with`),
+			},
+			{
+				Config: config(22),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckField(server, "hosts", "awx_host.test", "variables", `{"ansible_host":"10.0.0.1","ansible_port":22,"roles":["web","db"],"zip":"01234"}`),
+					resource.TestCheckResourceAttr("awx_host.test", "variables_map.zip", `"01234"`),
+				),
+			},
+			{
+				Config: config(2222),
+				Check:  testCheckField(server, "hosts", "awx_host.test", "variables", `{"ansible_host":"10.0.0.1","ansible_port":2222,"roles":["web","db"],"zip":"01234"}`),
+			},
+			{
+				// the same variables in YAML are no change
+				PreConfig: testUpdateObject(t, server, "hosts", "name", "test", map[string]interface{}{
+					"variables": "ansible_host: 10.0.0.1\nansible_port: 2222\nzip: '01234'\nroles: [web, db]\n",
+				}),
+				Config:   config(2222),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: testUpdateObject(t, server, "hosts", "name", "test", map[string]interface{}{
+					"variables": `{"ansible_host":"10.0.0.2","ansible_port":2222,"roles":["web","db"],"zip":"01234"}`,
+				}),
+				Config:             config(2222),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceHostVariablesMerge(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	config := func(variables string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_host" "test" {
+  name           = "test"
+  inventory_id   = %d
+  variables_mode = "merge"
+  variables_map  = %s
+}
+`, inventoryID, variables))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "hosts", "awx_host"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`{ ansible_host = "10.0.0.1", ansible_port = 22 }`),
+				Check:  testCheckField(server, "hosts", "awx_host.test", "variables", `{"ansible_host":"10.0.0.1","ansible_port":22}`),
+			},
+			{
+				// an inventory sync writes other variables
+				PreConfig: testUpdateObject(t, server, "hosts", "name", "test", map[string]interface{}{
+					"variables": "ansible_host: 10.0.0.1\nansible_port: 22\nec2_region: eu-west-1\n",
+				}),
+				Config:   config(`{ ansible_host = "10.0.0.1", ansible_port = 22 }`),
+				PlanOnly: true,
+			},
+			{
+				Config: config(`{ ansible_host = "10.0.0.2" }`),
+				Check:  testCheckField(server, "hosts", "awx_host.test", "variables", `{"ansible_host":"10.0.0.2","ec2_region":"eu-west-1"}`),
+			},
+			{
+				PreConfig: testUpdateObject(t, server, "hosts", "name", "test", map[string]interface{}{
+					"variables": `{"ansible_host":"10.0.0.3","ec2_region":"eu-west-1"}`,
+				}),
+				Config:             config(`{ ansible_host = "10.0.0.2" }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(`{ ansible_host = "10.0.0.2" }`),
+				Check:  testCheckField(server, "hosts", "awx_host.test", "variables", `{"ansible_host":"10.0.0.2","ec2_region":"eu-west-1"}`),
+			},
+		},
+	})
+}
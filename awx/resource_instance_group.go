@@ -55,14 +55,23 @@ func resourceInstanceGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("instance_groups"),
 		},
 	}
 }
 
 func resourceInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "instance_groups", map[string]interface{}{
+		"name": d.Get("name").(string),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceInstanceGroupUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.InstanceGroupsService
 
 	result, err := awxService.CreateInstanceGroup(map[string]interface{}{
@@ -82,7 +91,7 @@ func resourceInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceInstanceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InstanceGroupsService
 	id, diags := convertStateIDToNummeric(diagElementInstanceGroupTitle, d)
 	if diags.HasError() {
@@ -105,7 +114,7 @@ func resourceInstanceGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceInstanceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InstanceGroupsService
 
 	id, diags := convertStateIDToNummeric(diagElementInstanceGroupTitle, d)
@@ -125,19 +134,29 @@ func resourceInstanceGroupDelete(ctx context.Context, d *schema.ResourceData, m
 
 func resourceInstanceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.InstanceGroupsService
+	client := m.(*awxClient)
 
 	id, diags := convertStateIDToNummeric(diagElementInstanceGroupTitle, d)
 	if diags.HasError() {
 		return diags
 	}
 
-	res, err := awxService.GetInstanceGroupByID(id, make(map[string]string))
+	// goawx does not read the policy of the instance group
+	res := new(struct {
+		awx.InstanceGroup
+		PolicyInstanceMinimum    int `json:"policy_instance_minimum"`
+		PolicyInstancePercentage int `json:"policy_instance_percentage"`
+	})
+	err := client.getJSON(fmt.Sprintf("/api/v2/instance_groups/%d/", id), res, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, diagElementInstanceGroupTitle, err) {
+			return nil
+		}
 		return buildDiagNotFoundFail(diagElementInstanceGroupTitle, id, err)
 	}
-	d = setInstanceGroupResourceData(d, res)
+	d = setInstanceGroupResourceData(d, &res.InstanceGroup)
+	d.Set("policy_instance_minimum", res.PolicyInstanceMinimum)
+	d.Set("policy_instance_percentage", res.PolicyInstancePercentage)
 	return diags
 }
 
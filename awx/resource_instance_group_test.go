@@ -0,0 +1,66 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceInstanceGroup(t *testing.T) {
+	server := newTestServer(t)
+	config := func(podSpecOverride string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_instance_group" "test" {
+  name                       = "test"
+  is_container_group         = true
+  policy_instance_percentage = 50
+  pod_spec_override          = %q
+}
+`, podSpecOverride))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "instance_groups", "awx_instance_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "instance_groups", "awx_instance_group.test"),
+					testCheckField(server, "instance_groups", "awx_instance_group.test", "policy_instance_percentage", 50),
+				),
+			},
+			{
+				ResourceName:      "awx_instance_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_instance_group.test",
+				ImportState:       true,
+				ImportStateId:     "test",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "instance_groups", "awx_instance_group.test", "pod_spec_override", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "instance_groups", "name", "test", map[string]interface{}{"policy_instance_percentage": 10}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "instance_groups", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -50,7 +50,7 @@ func resourceInventory() *schema.Resource {
 				Default:  "",
 			},
 			"organization_id": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Required: true,
 			},
 			"kind": {
@@ -64,29 +64,64 @@ func resourceInventory() *schema.Resource {
 				Default:  "",
 			},
 			"variables": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Default:   "",
-				StateFunc: normalizeJsonYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"variables"},
+				Description:   "Variables as a map, the values holding JSON as numbers or the result of jsonencode being decoded. Conflicts with variables.",
 			},
+			"variables_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          variablesModeReplace,
+				ValidateDiagFunc: validateVariablesMode,
+				Description:      "`replace` to manage all the variables, `merge` to only manage the declared keys and leave the others, as the ones inventory syncs or playbooks write.",
+			},
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			numericIDStateUpgrader(0, "organization_id"),
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("inventories"),
 		},
 	}
 }
 
 func resourceInventoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "inventories", map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceInventoryUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.InventoriesService
 
+	variables, diags := variablesParam(d, client, "inventories", 0)
+	if diags.HasError() {
+		return diags
+	}
+
 	result, err := awxService.CreateInventory(map[string]interface{}{
 		"name":         d.Get("name").(string),
-		"organization": d.Get("organization_id").(string),
+		"organization": d.Get("organization_id").(int),
 		"description":  d.Get("description").(string),
 		"kind":         d.Get("kind").(string),
 		"host_filter":  d.Get("host_filter").(string),
-		"variables":    d.Get("variables").(string),
+		"variables":    variables,
 	}, map[string]string{})
 	if err != nil {
 		return buildDiagCreateFail(diagElementInventoryTitle, err)
@@ -98,19 +133,23 @@ func resourceInventoryCreate(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceInventoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InventoriesService
 	id, diags := convertStateIDToNummeric(diagElementInventoryTitle, d)
 	if diags.HasError() {
 		return diags
 	}
+	variables, diags := variablesParam(d, client, "inventories", id)
+	if diags.HasError() {
+		return diags
+	}
 	_, err := awxService.UpdateInventory(id, map[string]interface{}{
 		"name":         d.Get("name").(string),
-		"organization": d.Get("organization_id").(string),
+		"organization": d.Get("organization_id").(int),
 		"description":  d.Get("description").(string),
 		"kind":         d.Get("kind").(string),
 		"host_filter":  d.Get("host_filter").(string),
-		"variables":    d.Get("variables").(string),
+		"variables":    variables,
 	}, nil)
 	if err != nil {
 		return buildDiagUpdateFail(diagElementInventoryTitle, id, err)
@@ -121,7 +160,7 @@ func resourceInventoryUpdate(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InventoriesService
 	id, err := strconv.Atoi(d.Id())
 	id, diags := convertStateIDToNummeric(diagElementInventoryTitle, d)
@@ -130,6 +169,9 @@ func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	r, err := awxService.GetInventory(id, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, diagElementInventoryTitle, err) {
+			return nil
+		}
 		return buildDiagNotFoundFail(diagElementInventoryTitle, id, err)
 	}
 	d = setInventoryResourceData(d, r)
@@ -137,7 +179,7 @@ func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceInventoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InventoriesService
 	id, diags := convertStateIDToNummeric(diagElementInventoryTitle, d)
 	if diags.HasError() {
@@ -158,11 +200,11 @@ func resourceInventoryDelete(ctx context.Context, d *schema.ResourceData, m inte
 
 func setInventoryResourceData(d *schema.ResourceData, r *awx.Inventory) *schema.ResourceData {
 	d.Set("name", r.Name)
-	d.Set("organization_id", strconv.Itoa(r.Organization))
+	d.Set("organization_id", r.Organization)
 	d.Set("description", r.Description)
 	d.Set("kind", r.Kind)
 	d.Set("host_filter", r.HostFilter)
-	d.Set("variables", normalizeJsonYaml(r.Variables))
+	setVariablesOrMap(d, "variables", "variables_map", ownedVariables(d, r.Variables))
 	d.SetId(strconv.Itoa(r.ID))
 	return d
 }
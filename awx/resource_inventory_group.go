@@ -38,33 +38,68 @@ func resourceInventoryGroup() *schema.Resource {
 				Default:  "",
 			},
 			"inventory_id": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Optional: true,
 				ForceNew: true,
 			},
 			"variables": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Default:   "",
-				StateFunc: normalizeJsonYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"variables"},
+				Description:   "Variables as a map, the values holding JSON as numbers or the result of jsonencode being decoded. Conflicts with variables.",
 			},
+			"variables_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          variablesModeReplace,
+				ValidateDiagFunc: validateVariablesMode,
+				Description:      "`replace` to manage all the variables, `merge` to only manage the declared keys and leave the others, as the ones inventory syncs or playbooks write.",
+			},
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			numericIDStateUpgrader(0, "inventory_id"),
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("groups"),
 		},
 	}
 }
 
 func resourceInventoryGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "groups", map[string]interface{}{
+		"name":      d.Get("name").(string),
+		"inventory": d.Get("inventory_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceInventoryGroupUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.GroupService
 
+	variables, diags := variablesParam(d, client, "groups", 0)
+	if diags.HasError() {
+		return diags
+	}
+
 	result, err := awxService.CreateGroup(map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
-		"inventory":   d.Get("inventory_id").(string),
-		"variables":   d.Get("variables").(string),
+		"inventory":   d.Get("inventory_id").(int),
+		"variables":   variables,
 	}, map[string]string{})
 	if err != nil {
 		return buildDiagCreateFail(diagElementInventoryGroupTitle, err)
@@ -76,18 +111,22 @@ func resourceInventoryGroupCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceInventoryGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.GroupService
 	id, diags := convertStateIDToNummeric(diagElementInventoryGroupTitle, d)
 	if diags.HasError() {
 		return diags
 	}
 
+	variables, diags := variablesParam(d, client, "groups", id)
+	if diags.HasError() {
+		return diags
+	}
 	_, err := awxService.UpdateGroup(id, map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
-		"inventory":   d.Get("inventory_id").(string),
-		"variables":   d.Get("variables").(string),
+		"inventory":   d.Get("inventory_id").(int),
+		"variables":   variables,
 	}, nil)
 	if err != nil {
 		return buildDiagUpdateFail(diagElementInventoryGroupTitle, id, err)
@@ -98,7 +137,7 @@ func resourceInventoryGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceInventoryGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.GroupService
 
 	id, diags := convertStateIDToNummeric(diagElementInventoryGroupTitle, d)
@@ -118,7 +157,7 @@ func resourceInventoryGroupDelete(ctx context.Context, d *schema.ResourceData, m
 
 func resourceInventoryGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.GroupService
 
 	id, diags := convertStateIDToNummeric(diagElementInventoryGroupTitle, d)
@@ -128,6 +167,9 @@ func resourceInventoryGroupRead(ctx context.Context, d *schema.ResourceData, m i
 
 	res, err := awxService.GetGroupByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, diagElementInventoryGroupTitle, err) {
+			return nil
+		}
 		return buildDiagNotFoundFail(diagElementInventoryGroupTitle, id, err)
 	}
 	d = setInventoryGroupResourceData(d, res)
@@ -138,7 +180,7 @@ func setInventoryGroupResourceData(d *schema.ResourceData, r *awx.Group) *schema
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
 	d.Set("inventory_id", r.Inventory)
-	d.Set("variables", normalizeJsonYaml(r.Variables))
+	setVariablesOrMap(d, "variables", "variables_map", ownedVariables(d, r.Variables))
 
 	d.SetId(strconv.Itoa(r.ID))
 	return d
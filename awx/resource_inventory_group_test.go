@@ -0,0 +1,68 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceInventoryGroup(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_inventory_group" "test" {
+  name         = "test"
+  description  = %q
+  inventory_id = %d
+  variables    = "foo: bar"
+}
+`, description, inventoryID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "groups", "awx_inventory_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "groups", "awx_inventory_group.test"),
+					testCheckField(server, "groups", "awx_inventory_group.test", "inventory", inventoryID),
+				),
+			},
+			{
+				ResourceName:      "awx_inventory_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_inventory_group.test",
+				ImportState:       true,
+				ImportStateId:     "test++inventory++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "groups", "awx_inventory_group.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "groups", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "groups", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -69,13 +69,16 @@ func resourceInventorySource() *schema.Resource {
 				Optional: true,
 			},
 			"source": {
-				Type:     schema.TypeString,
-				Default:  "scm",
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          "scm",
+				Optional:         true,
+				ValidateDiagFunc: validateInventorySource,
 			},
 			"source_vars": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
 			},
 			"host_filter": {
 				Type:     schema.TypeString,
@@ -87,9 +90,10 @@ func resourceInventorySource() *schema.Resource {
 				Default:  30,
 			},
 			"verbosity": {
-				Type:     schema.TypeInt,
-				Default:  1,
-				Optional: true,
+				Type:             schema.TypeInt,
+				Default:          1,
+				Optional:         true,
+				ValidateDiagFunc: validateInventorySourceVerbosity,
 			},
 			// obsolete schema added so terraform doesn't break
 			// these don't do anything in later versions of AWX! Update your code.
@@ -115,13 +119,24 @@ func resourceInventorySource() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("inventory_sources"),
 		},
 	}
 }
 
 func resourceInventorySourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "inventory_sources", map[string]interface{}{
+		"name":      d.Get("name").(string),
+		"inventory": d.Get("inventory_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceInventorySourceUpdate(ctx, d, m)
+	}
+
+	versionDiags := checkVersionedAttributes(d, m, diagElementInventorySourceTitle, obsoleteInventorySourceAttributes...)
+	client := m.(*awxClient)
 	awxService := client.InventorySourcesService
 
 	createInventorySourceData := map[string]interface{}{
@@ -158,12 +173,13 @@ func resourceInventorySourceCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
-	return resourceInventorySourceRead(ctx, d, m)
+	return append(versionDiags, resourceInventorySourceRead(ctx, d, m)...)
 
 }
 
 func resourceInventorySourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	versionDiags := checkVersionedAttributes(d, m, diagElementInventorySourceTitle, obsoleteInventorySourceAttributes...)
+	client := m.(*awxClient)
 	awxService := client.InventorySourcesService
 	id, diags := convertStateIDToNummeric(diagElementInventorySourceTitle, d)
 	if diags.HasError() {
@@ -203,11 +219,11 @@ func resourceInventorySourceUpdate(ctx context.Context, d *schema.ResourceData,
 		return buildDiagUpdateFail(diagElementInventorySourceTitle, id, err)
 	}
 
-	return resourceInventorySourceRead(ctx, d, m)
+	return append(versionDiags, resourceInventorySourceRead(ctx, d, m)...)
 }
 
 func resourceInventorySourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InventorySourcesService
 	id, diags := convertStateIDToNummeric(diagElementInventorySourceTitle, d)
 	if diags.HasError() {
@@ -224,7 +240,7 @@ func resourceInventorySourceDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceInventorySourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.InventorySourcesService
 	id, diags := convertStateIDToNummeric(diagElementInventorySourceTitle, d)
 	if diags.HasError() {
@@ -232,6 +248,9 @@ func resourceInventorySourceRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	res, err := awxService.GetInventorySourceByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, diagElementInventorySourceTitle, err) {
+			return nil
+		}
 		return buildDiagNotFoundFail(diagElementInventorySourceTitle, id, err)
 	}
 	d = setInventorySourceResourceData(d, res)
@@ -249,7 +268,7 @@ func setInventorySourceResourceData(d *schema.ResourceData, r *awx.InventorySour
 	d.Set("inventory_id", r.Inventory)
 	d.Set("credential_id", r.Credential)
 	d.Set("source", r.Source)
-	d.Set("source_vars", normalizeJsonYaml(r.SourceVars))
+	setVariables(d, "source_vars", r.SourceVars)
 	d.Set("host_filter", r.HostFilter)
 	d.Set("update_cache_timeout", r.UpdateCacheTimeout)
 	d.Set("verbosity", r.Verbosity)
@@ -0,0 +1,72 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceInventorySource(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_inventory_source" "test" {
+  name              = "test"
+  description       = %q
+  inventory_id      = %d
+  source            = "scm"
+  source_project_id = %d
+  source_path       = "hosts.yml"
+}
+`, description, inventoryID, projectID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "inventory_sources", "awx_inventory_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "inventory_sources", "awx_inventory_source.test"),
+					testCheckField(server, "inventory_sources", "awx_inventory_source.test", "source_project", projectID),
+					resource.TestCheckResourceAttr("awx_inventory_source.test", "source_path", "hosts.yml"),
+				),
+			},
+			{
+				ResourceName:      "awx_inventory_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_inventory_source.test",
+				ImportState:       true,
+				ImportStateId:     "test++inventory++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "inventory_sources", "awx_inventory_source.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "inventory_sources", "name", "test", map[string]interface{}{"overwrite": false}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "inventory_sources", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
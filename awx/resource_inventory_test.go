@@ -0,0 +1,74 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceInventory(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_inventory" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+  variables       = "foo: bar"
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "inventories", "awx_inventory"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "inventories", "awx_inventory.test"),
+					testCheckField(server, "inventories", "awx_inventory.test", "organization", orgID),
+					testCheckField(server, "inventories", "awx_inventory.test", "variables", "foo: bar"),
+				),
+			},
+			{
+				ResourceName:      "awx_inventory.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_inventory.test",
+				ImportState:       true,
+				ImportStateId:     "test++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "inventories", "awx_inventory.test", "description", "updated"),
+			},
+			{
+				// the same variables in JSON are no change
+				PreConfig: testUpdateObject(t, server, "inventories", "name", "test", map[string]interface{}{"variables": `{"foo": "bar"}`}),
+				Config:    config("updated"),
+				PlanOnly:  true,
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "inventories", "name", "test", map[string]interface{}{"variables": "foo: baz"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "inventories", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
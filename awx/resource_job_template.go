@@ -19,6 +19,14 @@ resource "awx_job_template" "baseconfig" {
 }
 ```
 
+Import
+
+Job templates can be imported by ID or by AWX named URL, `<name>++<organization>`.
+
+```shell
+terraform import awx_job_template.baseconfig 'baseconfig++Default'
+```
+
 */
 package awx
 
@@ -29,342 +37,708 @@ import (
 	"strconv"
 
 	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func resourceJobTemplate() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceJobTemplateCreate,
-		ReadContext:   resourceJobTemplateRead,
-		UpdateContext: resourceJobTemplateUpdate,
-		DeleteContext: resourceJobTemplateDelete,
+var (
+	_ resource.ResourceWithConfigure    = &jobTemplateResource{}
+	_ resource.ResourceWithImportState  = &jobTemplateResource{}
+	_ resource.ResourceWithModifyPlan   = &jobTemplateResource{}
+	_ resource.ResourceWithUpgradeState = &jobTemplateResource{}
+)
+
+func newJobTemplateResource() resource.Resource {
+	return &jobTemplateResource{}
+}
+
+// jobTemplateResource manages awx_job_template on the plugin framework.
+type jobTemplateResource struct {
+	client *awxClient
+}
+
+type jobTemplateResourceModel struct {
+	ID                              types.String   `tfsdk:"id"`
+	Name                            types.String   `tfsdk:"name"`
+	Description                     types.String   `tfsdk:"description"`
+	JobType                         types.String   `tfsdk:"job_type"`
+	InventoryID                     types.Int64    `tfsdk:"inventory_id"`
+	ProjectID                       types.Int64    `tfsdk:"project_id"`
+	Playbook                        types.String   `tfsdk:"playbook"`
+	Forks                           types.Int64    `tfsdk:"forks"`
+	Limit                           types.String   `tfsdk:"limit"`
+	Verbosity                       types.Int64    `tfsdk:"verbosity"`
+	ExtraVars                       jsonYAMLString `tfsdk:"extra_vars"`
+	ExtraVarsMap                    types.Map      `tfsdk:"extra_vars_map"`
+	JobTags                         types.String   `tfsdk:"job_tags"`
+	ForceHandlers                   types.Bool     `tfsdk:"force_handlers"`
+	SkipTags                        types.String   `tfsdk:"skip_tags"`
+	StartAtTask                     types.String   `tfsdk:"start_at_task"`
+	Timeout                         types.Int64    `tfsdk:"timeout"`
+	UseFactCache                    types.Bool     `tfsdk:"use_fact_cache"`
+	HostConfigKey                   types.String   `tfsdk:"host_config_key"`
+	AskDiffModeOnLaunch             types.Bool     `tfsdk:"ask_diff_mode_on_launch"`
+	AskLimitOnLaunch                types.Bool     `tfsdk:"ask_limit_on_launch"`
+	AskTagsOnLaunch                 types.Bool     `tfsdk:"ask_tags_on_launch"`
+	AskVerbosityOnLaunch            types.Bool     `tfsdk:"ask_verbosity_on_launch"`
+	AskInventoryOnLaunch            types.Bool     `tfsdk:"ask_inventory_on_launch"`
+	AskVariablesOnLaunch            types.Bool     `tfsdk:"ask_variables_on_launch"`
+	AskCredentialOnLaunch           types.Bool     `tfsdk:"ask_credential_on_launch"`
+	SurveyEnabled                   types.Bool     `tfsdk:"survey_enabled"`
+	BecomeEnabled                   types.Bool     `tfsdk:"become_enabled"`
+	DiffMode                        types.Bool     `tfsdk:"diff_mode"`
+	AskSkipTagsOnLaunch             types.Bool     `tfsdk:"ask_skip_tags_on_launch"`
+	AllowSimultaneous               types.Bool     `tfsdk:"allow_simultaneous"`
+	CustomVirtualenv                types.String   `tfsdk:"custom_virtualenv"`
+	AskJobTypeOnLaunch              types.Bool     `tfsdk:"ask_job_type_on_launch"`
+	ExecutionEnvironment            types.Int64    `tfsdk:"execution_environment"`
+	ScmBranch                       types.String   `tfsdk:"scm_branch"`
+	JobSliceCount                   types.Int64    `tfsdk:"job_slice_count"`
+	WebhookService                  types.String   `tfsdk:"webhook_service"`
+	WebhookCredential               types.Int64    `tfsdk:"webhook_credential"`
+	PreventInstanceGroupFallback    types.Bool     `tfsdk:"prevent_instance_group_fallback"`
+	AskScmBranchOnLaunch            types.Bool     `tfsdk:"ask_scm_branch_on_launch"`
+	AskExecutionEnvironmentOnLaunch types.Bool     `tfsdk:"ask_execution_environment_on_launch"`
+	AskLabelsOnLaunch               types.Bool     `tfsdk:"ask_labels_on_launch"`
+	AskForksOnLaunch                types.Bool     `tfsdk:"ask_forks_on_launch"`
+	AskJobSliceCountOnLaunch        types.Bool     `tfsdk:"ask_job_slice_count_on_launch"`
+	AskTimeoutOnLaunch              types.Bool     `tfsdk:"ask_timeout_on_launch"`
+	AskInstanceGroupsOnLaunch       types.Bool     `tfsdk:"ask_instance_groups_on_launch"`
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
+func (r *jobTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_template"
+}
+
+func (r *jobTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
 				Required: true,
 			},
-			"description": {
-				Type:     schema.TypeString,
+			"description": schema.StringAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			// Run, Check, Scan
-			"job_type": {
-				Type:        schema.TypeString,
+			// Run, Check
+			"job_type": schema.StringAttribute{
 				Required:    true,
-				Description: "One of: run, check, scan",
+				Description: "One of: run, check",
+				Validators: []validator.String{
+					stringvalidator.OneOf(jobTypes...),
+				},
 			},
-			"inventory_id": {
-				Type:     schema.TypeString,
+			"inventory_id": schema.Int64Attribute{
 				Required: true,
 			},
-			"project_id": {
-				Type:     schema.TypeInt,
+			"project_id": schema.Int64Attribute{
 				Required: true,
 			},
-			"playbook": {
-				Type:     schema.TypeString,
+			"playbook": schema.StringAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"forks": {
-				Type:     schema.TypeInt,
+			"forks": schema.Int64Attribute{
 				Optional: true,
-				Default:  0,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"limit": {
-				Type:     schema.TypeString,
+			"limit": schema.StringAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
 			//0,1,2,3,4,5
-			"verbosity": {
-				Type:        schema.TypeInt,
+			"verbosity": schema.Int64Attribute{
 				Optional:    true,
-				Default:     0,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
 				Description: "One of 0,1,2,3,4,5",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 5),
+				},
+			},
+			"extra_vars": schema.StringAttribute{
+				CustomType: jsonYAMLStringType{},
+				Optional:   true,
+				Computed:   true,
+				Default:    stringdefault.StaticString(""),
+				Validators: []validator.String{
+					jsonYAMLObjectValidator{},
+				},
+			},
+			"extra_vars_map": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Extra variables as a map, the values holding JSON as numbers or the result of jsonencode being decoded. Conflicts with extra_vars.",
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("extra_vars")),
+				},
 			},
-			"extra_vars": {
-				Type:     schema.TypeString,
+			"job_tags": schema.StringAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"job_tags": {
-				Type:     schema.TypeString,
+			"force_handlers": schema.BoolAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"force_handlers": {
-				Type:     schema.TypeBool,
+			"skip_tags": schema.StringAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"skip_tags": {
-				Type:     schema.TypeString,
+			"start_at_task": schema.StringAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"start_at_task": {
-				Type:     schema.TypeString,
+			"timeout": schema.Int64Attribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
 			},
-			"timeout": {
-				Type:     schema.TypeInt,
+			"use_fact_cache": schema.BoolAttribute{
 				Optional: true,
-				Default:  0,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"use_fact_cache": {
-				Type:     schema.TypeBool,
+			"host_config_key": schema.StringAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"host_config_key": {
-				Type:     schema.TypeString,
+			"ask_diff_mode_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_diff_mode_on_launch": {
-				Type:     schema.TypeBool,
+			"ask_limit_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_limit_on_launch": {
-				Type:     schema.TypeBool,
+			"ask_tags_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_tags_on_launch": {
-				Type:     schema.TypeBool,
+			"ask_verbosity_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_verbosity_on_launch": {
-				Type:     schema.TypeBool,
+			"ask_inventory_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_inventory_on_launch": {
-				Type:     schema.TypeBool,
+			"ask_variables_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_variables_on_launch": {
-				Type:     schema.TypeBool,
+			"ask_credential_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_credential_on_launch": {
-				Type:     schema.TypeBool,
+			"survey_enabled": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"survey_enabled": {
-				Type:     schema.TypeBool,
+			"become_enabled": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"become_enabled": {
-				Type:     schema.TypeBool,
+			"diff_mode": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"diff_mode": {
-				Type:     schema.TypeBool,
+			"ask_skip_tags_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_skip_tags_on_launch": {
-				Type:     schema.TypeBool,
+			"allow_simultaneous": schema.BoolAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"allow_simultaneous": {
-				Type:     schema.TypeBool,
+			"custom_virtualenv": schema.StringAttribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"custom_virtualenv": {
-				Type:     schema.TypeString,
+			"ask_job_type_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
-			"ask_job_type_on_launch": {
-				Type:     schema.TypeBool,
+			"execution_environment": schema.Int64Attribute{
 				Optional: true,
-				Default:  false,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+			"scm_branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Branch to use in job runs, overriding the one of the project when it allows it.",
 			},
-			"execution_environment": {
-				Type:     schema.TypeString,
+			"job_slice_count": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Description: "Number of slices the job is divided into, each running on a part of the inventory.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"webhook_service": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Service that can launch the job template through a webhook. One of: github, gitlab, bitbucket_dc",
+				Validators: []validator.String{
+					stringvalidator.OneOf(webhookServices...),
+				},
+			},
+			"webhook_credential": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Description: "Personal access token credential used to post back the status of the jobs to the webhook service.",
+			},
+			"prevent_instance_group_fallback": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Run the jobs on the instance groups of the job template only, not on the ones of its inventory and organization.",
+			},
+			"ask_scm_branch_on_launch": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ask_execution_environment_on_launch": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ask_labels_on_launch": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ask_forks_on_launch": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ask_job_slice_count_on_launch": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ask_timeout_on_launch": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ask_instance_groups_on_launch": schema.BoolAttribute{
 				Optional: true,
-				Default:  "",
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 		},
 	}
 }
 
-func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.JobTemplateService
-
-	result, err := awxService.CreateJobTemplate(map[string]interface{}{
-		"name":                     d.Get("name").(string),
-		"description":              d.Get("description").(string),
-		"job_type":                 d.Get("job_type").(string),
-		"inventory":                AtoipOr(d.Get("inventory_id").(string), nil),
-		"project":                  d.Get("project_id").(int),
-		"playbook":                 d.Get("playbook").(string),
-		"forks":                    d.Get("forks").(int),
-		"limit":                    d.Get("limit").(string),
-		"verbosity":                d.Get("verbosity").(int),
-		"extra_vars":               d.Get("extra_vars").(string),
-		"job_tags":                 d.Get("job_tags").(string),
-		"force_handlers":           d.Get("force_handlers").(bool),
-		"skip_tags":                d.Get("skip_tags").(string),
-		"start_at_task":            d.Get("start_at_task").(string),
-		"timeout":                  d.Get("timeout").(int),
-		"use_fact_cache":           d.Get("use_fact_cache").(bool),
-		"host_config_key":          d.Get("host_config_key").(string),
-		"ask_diff_mode_on_launch":  d.Get("ask_diff_mode_on_launch").(bool),
-		"ask_variables_on_launch":  d.Get("ask_variables_on_launch").(bool),
-		"ask_limit_on_launch":      d.Get("ask_limit_on_launch").(bool),
-		"ask_tags_on_launch":       d.Get("ask_tags_on_launch").(bool),
-		"ask_skip_tags_on_launch":  d.Get("ask_skip_tags_on_launch").(bool),
-		"ask_job_type_on_launch":   d.Get("ask_job_type_on_launch").(bool),
-		"ask_verbosity_on_launch":  d.Get("ask_verbosity_on_launch").(bool),
-		"ask_inventory_on_launch":  d.Get("ask_inventory_on_launch").(bool),
-		"ask_credential_on_launch": d.Get("ask_credential_on_launch").(bool),
-		"survey_enabled":           d.Get("survey_enabled").(bool),
-		"become_enabled":           d.Get("become_enabled").(bool),
-		"diff_mode":                d.Get("diff_mode").(bool),
-		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
-		"custom_virtualenv":        AtoipOr(d.Get("custom_virtualenv").(string), nil),
-		"execution_environment":    AtoipOr(d.Get("execution_environment").(string), nil),
-	}, map[string]string{})
-	if err != nil {
-		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplate with name %s in the project id %d, failed to create %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+// UpgradeState converts the state written before the reference attributes
+// were numbers, by the SDKv2 implementation of the resource.
+func (r *jobTemplateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: frameworkStateUpgrader(numericIDStateUpgrader(0, "inventory_id", "execution_environment")),
 	}
+}
 
-	d.SetId(strconv.Itoa(result.ID))
-	return resourceJobTemplateRead(ctx, d, m)
+func (r *jobTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = frameworkClient(req, resp)
 }
 
-func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.JobTemplateService
-	id, diags := convertStateIDToNummeric("Update JobTemplate", d)
-	if diags.HasError() {
-		return diags
+// ModifyPlan checks the attributes depending on the controller version, so
+// the plan fails rather than the apply on an attribute the controller rejects.
+func (r *jobTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
 	}
+	var data jobTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	appendSDKDiagnostics(&resp.Diagnostics, data.checkVersionedAttributes(r.client))
+}
 
-	params := make(map[string]string)
-	_, err := awxService.GetJobTemplateByID(id, params)
+func (r *jobTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveIDOrNamedURL(r.client, "job_templates", req.ID)
 	if err != nil {
-		return buildDiagNotFoundFail("job template", id, err)
+		resp.Diagnostics.AddError("Unable to import JobTemplate", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(id))...)
+}
+
+func (r *jobTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data jobTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// the values unknown at plan time are only known here
+	appendSDKDiagnostics(&resp.Diagnostics, versionedAttributeErrors(data.checkVersionedAttributes(r.client)))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	awxService := r.client.JobTemplateService
+	id, diags := data.findExisting(r.client)
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if id != 0 {
+		_, err := awxService.UpdateJobTemplate(id, data.apiParams(), map[string]string{})
+		if err != nil {
+			appendSDKDiagnostics(&resp.Diagnostics, buildDiagAPIFail(
+				"Unable to update JobTemplate",
+				err,
+				"JobTemplate with name %s in the project id %d failed to update", data.Name.ValueString(), data.ProjectID.ValueInt64(),
+			))
+			return
+		}
+	} else {
+		result, err := awxService.CreateJobTemplate(data.apiParams(), map[string]string{})
+		if err != nil {
+			log.Printf("Fail to Create Template %v", err)
+			appendSDKDiagnostics(&resp.Diagnostics, buildDiagAPIFail(
+				"Unable to create JobTemplate",
+				err,
+				"JobTemplate with name %s in the project id %d, failed to create", data.Name.ValueString(), data.ProjectID.ValueInt64(),
+			))
+			return
+		}
+		id = result.ID
 	}
 
-	_, err = awxService.UpdateJobTemplate(id, map[string]interface{}{
-		"name":                     d.Get("name").(string),
-		"description":              d.Get("description").(string),
-		"job_type":                 d.Get("job_type").(string),
-		"inventory":                AtoipOr(d.Get("inventory_id").(string), nil),
-		"project":                  d.Get("project_id").(int),
-		"playbook":                 d.Get("playbook").(string),
-		"forks":                    d.Get("forks").(int),
-		"limit":                    d.Get("limit").(string),
-		"verbosity":                d.Get("verbosity").(int),
-		"extra_vars":               d.Get("extra_vars").(string),
-		"job_tags":                 d.Get("job_tags").(string),
-		"force_handlers":           d.Get("force_handlers").(bool),
-		"skip_tags":                d.Get("skip_tags").(string),
-		"start_at_task":            d.Get("start_at_task").(string),
-		"timeout":                  d.Get("timeout").(int),
-		"use_fact_cache":           d.Get("use_fact_cache").(bool),
-		"host_config_key":          d.Get("host_config_key").(string),
-		"ask_diff_mode_on_launch":  d.Get("ask_diff_mode_on_launch").(bool),
-		"ask_variables_on_launch":  d.Get("ask_variables_on_launch").(bool),
-		"ask_limit_on_launch":      d.Get("ask_limit_on_launch").(bool),
-		"ask_tags_on_launch":       d.Get("ask_tags_on_launch").(bool),
-		"ask_skip_tags_on_launch":  d.Get("ask_skip_tags_on_launch").(bool),
-		"ask_job_type_on_launch":   d.Get("ask_job_type_on_launch").(bool),
-		"ask_verbosity_on_launch":  d.Get("ask_verbosity_on_launch").(bool),
-		"ask_inventory_on_launch":  d.Get("ask_inventory_on_launch").(bool),
-		"ask_credential_on_launch": d.Get("ask_credential_on_launch").(bool),
-		"survey_enabled":           d.Get("survey_enabled").(bool),
-		"become_enabled":           d.Get("become_enabled").(bool),
-		"diff_mode":                d.Get("diff_mode").(bool),
-		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
-		"custom_virtualenv":        AtoipOr(d.Get("custom_virtualenv").(string), nil),
-		"execution_environment":    AtoipOr(d.Get("execution_environment").(string), nil),
-	}, map[string]string{})
+	res, err := r.getJobTemplate(id)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplate with name %s in the project id %d failed to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagNotFoundFail("job template", id, err))
+		return
 	}
+	data.setFromAPI(res)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	return resourceJobTemplateRead(ctx, d, m)
+func (r *jobTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data jobTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, diags := convertIDToNummeric("Read JobTemplate", data.ID.ValueString())
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.getJobTemplate(id)
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] job template %d not found, removing it from the state", id)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagNotFoundFail("job template", id, err))
+		return
+	}
+	data.setFromAPI(res)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.JobTemplateService
-	id, diags := convertStateIDToNummeric("Read JobTemplate", d)
-	if diags.HasError() {
-		return diags
+func (r *jobTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data jobTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// the values unknown at plan time are only known here
+	appendSDKDiagnostics(&resp.Diagnostics, versionedAttributeErrors(data.checkVersionedAttributes(r.client)))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, diags := convertIDToNummeric("Update JobTemplate", data.ID.ValueString())
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	res, err := awxService.GetJobTemplateByID(id, make(map[string]string))
+	awxService := r.client.JobTemplateService
+	_, err := awxService.GetJobTemplateByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("job template", id, err)
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagNotFoundFail("job template", id, err))
+		return
+	}
+
+	_, err = awxService.UpdateJobTemplate(id, data.apiParams(), map[string]string{})
+	if err != nil {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagAPIFail(
+			"Unable to update JobTemplate",
+			err,
+			"JobTemplate with name %s in the project id %d failed to update", data.Name.ValueString(), data.ProjectID.ValueInt64(),
+		))
+		return
+	}
 
+	res, err := r.getJobTemplate(id)
+	if err != nil {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagNotFoundFail("job template", id, err))
+		return
 	}
-	d = setJobTemplateResourceData(d, res)
-	return nil
+	data.setFromAPI(res)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *schema.ResourceData {
-	d.Set("allow_simultaneous", r.AllowSimultaneous)
-	d.Set("ask_credential_on_launch", r.AskCredentialOnLaunch)
-	d.Set("ask_job_type_on_launch", r.AskJobTypeOnLaunch)
-	d.Set("ask_limit_on_launch", r.AskLimitOnLaunch)
-	d.Set("ask_skip_tags_on_launch", r.AskSkipTagsOnLaunch)
-	d.Set("ask_tags_on_launch", r.AskTagsOnLaunch)
-	d.Set("ask_variables_on_launch", r.AskVariablesOnLaunch)
-	d.Set("description", r.Description)
-	d.Set("extra_vars", normalizeJsonYaml(r.ExtraVars))
-	d.Set("force_handlers", r.ForceHandlers)
-	d.Set("forks", r.Forks)
-	d.Set("host_config_key", r.HostConfigKey)
-	d.Set("inventory_id", r.Inventory)
-	d.Set("job_tags", r.JobTags)
-	d.Set("job_type", r.JobType)
-	d.Set("diff_mode", r.DiffMode)
-	d.Set("custom_virtualenv", r.CustomVirtualenv)
-	d.Set("limit", r.Limit)
-	d.Set("name", r.Name)
-	d.Set("become_enabled", r.BecomeEnabled)
-	d.Set("use_fact_cache", r.UseFactCache)
-	d.Set("playbook", r.Playbook)
-	d.Set("project_id", r.Project)
-	d.Set("skip_tags", r.SkipTags)
-	d.Set("start_at_task", r.StartAtTask)
-	d.Set("survey_enabled", r.SurveyEnabled)
-	d.Set("verbosity", r.Verbosity)
-	d.SetId(strconv.Itoa(r.ID))
-	return d
+func (r *jobTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data jobTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, diags := convertIDToNummeric("Delete JobTemplate", data.ID.ValueString())
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.JobTemplateService.DeleteJobTemplate(id); err != nil {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagDeleteFail(
+			"JobTemplate",
+			fmt.Sprintf("JobTemplateID %v, got %s ", id, err.Error()),
+		))
+	}
+}
+
+// versionedAttributeErrors drops the warnings of checkVersionedAttributes,
+// ModifyPlan having reported them already.
+func versionedAttributeErrors(diags diag.Diagnostics) diag.Diagnostics {
+	var errors diag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errors = append(errors, d)
+		}
+	}
+	return errors
+}
+
+// findExisting looks up the job template to adopt. AWX enforces the name
+// uniqueness of job templates per organization, the one of their project.
+func (data *jobTemplateResourceModel) findExisting(client *awxClient) (int, diag.Diagnostics) {
+	if !client.adoptExisting {
+		return 0, nil
+	}
+	project := new(struct {
+		Organization int `json:"organization"`
+	})
+	if err := client.getJSON(fmt.Sprintf("/api/v2/projects/%d/", data.ProjectID.ValueInt64()), project, map[string]string{}); err != nil {
+		return 0, buildDiagNotFoundFail("project", int(data.ProjectID.ValueInt64()), err)
+	}
+	return findExistingObject(client, "job_templates", map[string]interface{}{
+		"name":         data.Name.ValueString(),
+		"organization": project.Organization,
+	})
+}
+
+// checkVersionedAttributes checks the attributes depending on the controller
+// version, set when they differ from their default.
+func (data *jobTemplateResourceModel) checkVersionedAttributes(client *awxClient) diag.Diagnostics {
+	isSet := func(attribute string) bool {
+		switch attribute {
+		case "execution_environment":
+			return data.ExecutionEnvironment.ValueInt64() != 0
+		case "custom_virtualenv":
+			return data.CustomVirtualenv.ValueString() != ""
+		case "prevent_instance_group_fallback":
+			return data.PreventInstanceGroupFallback.ValueBool()
+		case "ask_execution_environment_on_launch":
+			return data.AskExecutionEnvironmentOnLaunch.ValueBool()
+		case "ask_labels_on_launch":
+			return data.AskLabelsOnLaunch.ValueBool()
+		case "ask_forks_on_launch":
+			return data.AskForksOnLaunch.ValueBool()
+		case "ask_job_slice_count_on_launch":
+			return data.AskJobSliceCountOnLaunch.ValueBool()
+		case "ask_timeout_on_launch":
+			return data.AskTimeoutOnLaunch.ValueBool()
+		case "ask_instance_groups_on_launch":
+			return data.AskInstanceGroupsOnLaunch.ValueBool()
+		}
+		return false
+	}
+	attributes := append([]versionedAttribute{attributeExecutionEnvironment, attributeCustomVirtualenv, attributePreventInstanceGroupFallback}, launchPromptAttributes...)
+	return checkVersionedAttributesSet(client.controller, isSet, "JobTemplate", attributes...)
+}
+
+func (data *jobTemplateResourceModel) apiParams() map[string]interface{} {
+	return map[string]interface{}{
+		"name":                                data.Name.ValueString(),
+		"description":                         data.Description.ValueString(),
+		"job_type":                            data.JobType.ValueString(),
+		"inventory":                           data.InventoryID.ValueInt64(),
+		"project":                             data.ProjectID.ValueInt64(),
+		"playbook":                            data.Playbook.ValueString(),
+		"forks":                               data.Forks.ValueInt64(),
+		"limit":                               data.Limit.ValueString(),
+		"verbosity":                           data.Verbosity.ValueInt64(),
+		"extra_vars":                          variablesText(data.ExtraVars, data.ExtraVarsMap),
+		"job_tags":                            data.JobTags.ValueString(),
+		"force_handlers":                      data.ForceHandlers.ValueBool(),
+		"skip_tags":                           data.SkipTags.ValueString(),
+		"start_at_task":                       data.StartAtTask.ValueString(),
+		"timeout":                             data.Timeout.ValueInt64(),
+		"use_fact_cache":                      data.UseFactCache.ValueBool(),
+		"host_config_key":                     data.HostConfigKey.ValueString(),
+		"ask_diff_mode_on_launch":             data.AskDiffModeOnLaunch.ValueBool(),
+		"ask_variables_on_launch":             data.AskVariablesOnLaunch.ValueBool(),
+		"ask_limit_on_launch":                 data.AskLimitOnLaunch.ValueBool(),
+		"ask_tags_on_launch":                  data.AskTagsOnLaunch.ValueBool(),
+		"ask_skip_tags_on_launch":             data.AskSkipTagsOnLaunch.ValueBool(),
+		"ask_job_type_on_launch":              data.AskJobTypeOnLaunch.ValueBool(),
+		"ask_verbosity_on_launch":             data.AskVerbosityOnLaunch.ValueBool(),
+		"ask_inventory_on_launch":             data.AskInventoryOnLaunch.ValueBool(),
+		"ask_credential_on_launch":            data.AskCredentialOnLaunch.ValueBool(),
+		"survey_enabled":                      data.SurveyEnabled.ValueBool(),
+		"become_enabled":                      data.BecomeEnabled.ValueBool(),
+		"diff_mode":                           data.DiffMode.ValueBool(),
+		"allow_simultaneous":                  data.AllowSimultaneous.ValueBool(),
+		"custom_virtualenv":                   StringpOrNil(data.CustomVirtualenv.ValueString()),
+		"execution_environment":               IntpOrNil(int(data.ExecutionEnvironment.ValueInt64())),
+		"scm_branch":                          data.ScmBranch.ValueString(),
+		"job_slice_count":                     data.JobSliceCount.ValueInt64(),
+		"webhook_service":                     data.WebhookService.ValueString(),
+		"webhook_credential":                  IntpOrNil(int(data.WebhookCredential.ValueInt64())),
+		"prevent_instance_group_fallback":     data.PreventInstanceGroupFallback.ValueBool(),
+		"ask_scm_branch_on_launch":            data.AskScmBranchOnLaunch.ValueBool(),
+		"ask_execution_environment_on_launch": data.AskExecutionEnvironmentOnLaunch.ValueBool(),
+		"ask_labels_on_launch":                data.AskLabelsOnLaunch.ValueBool(),
+		"ask_forks_on_launch":                 data.AskForksOnLaunch.ValueBool(),
+		"ask_job_slice_count_on_launch":       data.AskJobSliceCountOnLaunch.ValueBool(),
+		"ask_timeout_on_launch":               data.AskTimeoutOnLaunch.ValueBool(),
+		"ask_instance_groups_on_launch":       data.AskInstanceGroupsOnLaunch.ValueBool(),
+	}
+}
+
+// jobTemplate is a job template as AWX returns it, with the fields of the
+// AWX 23 serializer the goawx type lacks.
+type jobTemplate struct {
+	awx.JobTemplate
+	ScmBranch                       string `json:"scm_branch"`
+	WebhookService                  string `json:"webhook_service"`
+	WebhookCredential               int    `json:"webhook_credential"`
+	PreventInstanceGroupFallback    bool   `json:"prevent_instance_group_fallback"`
+	AskScmBranchOnLaunch            bool   `json:"ask_scm_branch_on_launch"`
+	AskExecutionEnvironmentOnLaunch bool   `json:"ask_execution_environment_on_launch"`
+	AskLabelsOnLaunch               bool   `json:"ask_labels_on_launch"`
+	AskForksOnLaunch                bool   `json:"ask_forks_on_launch"`
+	AskJobSliceCountOnLaunch        bool   `json:"ask_job_slice_count_on_launch"`
+	AskTimeoutOnLaunch              bool   `json:"ask_timeout_on_launch"`
+	AskInstanceGroupsOnLaunch       bool   `json:"ask_instance_groups_on_launch"`
+}
+
+// getJobTemplate reads the job template id.
+func (r *jobTemplateResource) getJobTemplate(id int) (*jobTemplate, error) {
+	res := new(jobTemplate)
+	err := r.client.getJSON(fmt.Sprintf("/api/v2/job_templates/%d/", id), res, map[string]string{})
+	return res, err
+}
+
+func (data *jobTemplateResourceModel) setFromAPI(r *jobTemplate) {
+	data.ID = types.StringValue(strconv.Itoa(r.ID))
+	data.Name = types.StringValue(r.Name)
+	data.Description = types.StringValue(r.Description)
+	data.JobType = types.StringValue(r.JobType)
+	data.InventoryID = types.Int64Value(int64(r.Inventory))
+	data.ProjectID = types.Int64Value(int64(r.Project))
+	data.Playbook = types.StringValue(r.Playbook)
+	data.Forks = types.Int64Value(int64(r.Forks))
+	data.Limit = types.StringValue(r.Limit)
+	data.Verbosity = types.Int64Value(int64(r.Verbosity))
+	setVariablesText(&data.ExtraVars, &data.ExtraVarsMap, r.ExtraVars)
+	data.JobTags = types.StringValue(r.JobTags)
+	data.ForceHandlers = types.BoolValue(r.ForceHandlers)
+	data.SkipTags = types.StringValue(r.SkipTags)
+	data.StartAtTask = types.StringValue(r.StartAtTask)
+	data.Timeout = types.Int64Value(int64(r.Timeout))
+	data.UseFactCache = types.BoolValue(r.UseFactCache)
+	data.HostConfigKey = types.StringValue(r.HostConfigKey)
+	data.AskDiffModeOnLaunch = types.BoolValue(r.AskDiffModeOnLaunch)
+	data.AskLimitOnLaunch = types.BoolValue(r.AskLimitOnLaunch)
+	data.AskTagsOnLaunch = types.BoolValue(r.AskTagsOnLaunch)
+	data.AskVerbosityOnLaunch = types.BoolValue(r.AskVerbosityOnLaunch)
+	data.AskInventoryOnLaunch = types.BoolValue(r.AskInventoryOnLaunch)
+	data.AskVariablesOnLaunch = types.BoolValue(r.AskVariablesOnLaunch)
+	data.AskCredentialOnLaunch = types.BoolValue(r.AskCredentialOnLaunch)
+	data.SurveyEnabled = types.BoolValue(r.SurveyEnabled)
+	data.BecomeEnabled = types.BoolValue(r.BecomeEnabled)
+	data.DiffMode = types.BoolValue(r.DiffMode)
+	data.AskSkipTagsOnLaunch = types.BoolValue(r.AskSkipTagsOnLaunch)
+	data.AllowSimultaneous = types.BoolValue(r.AllowSimultaneous)
+	data.AskJobTypeOnLaunch = types.BoolValue(r.AskJobTypeOnLaunch)
+	data.ExecutionEnvironment = types.Int64Value(int64(r.ExecutionEnvironment))
+	data.ScmBranch = types.StringValue(r.ScmBranch)
+	data.JobSliceCount = types.Int64Value(int64(r.JobSliceCount))
+	data.WebhookService = types.StringValue(r.WebhookService)
+	data.WebhookCredential = types.Int64Value(int64(r.WebhookCredential))
+	data.PreventInstanceGroupFallback = types.BoolValue(r.PreventInstanceGroupFallback)
+	data.AskScmBranchOnLaunch = types.BoolValue(r.AskScmBranchOnLaunch)
+	data.AskExecutionEnvironmentOnLaunch = types.BoolValue(r.AskExecutionEnvironmentOnLaunch)
+	data.AskLabelsOnLaunch = types.BoolValue(r.AskLabelsOnLaunch)
+	data.AskForksOnLaunch = types.BoolValue(r.AskForksOnLaunch)
+	data.AskJobSliceCountOnLaunch = types.BoolValue(r.AskJobSliceCountOnLaunch)
+	data.AskTimeoutOnLaunch = types.BoolValue(r.AskTimeoutOnLaunch)
+	data.AskInstanceGroupsOnLaunch = types.BoolValue(r.AskInstanceGroupsOnLaunch)
+	// custom_virtualenv is null on controllers ignoring it, the configured
+	// value is kept as checkVersionedAttributes already warned about it
+	if customVirtualenv, ok := r.CustomVirtualenv.(string); ok {
+		data.CustomVirtualenv = types.StringValue(customVirtualenv)
+	} else if data.CustomVirtualenv.IsNull() {
+		data.CustomVirtualenv = types.StringValue("")
+	}
 }
@@ -10,15 +10,21 @@ resource "awx_job_template_credential" "baseconfig" {
 }
 ```
 
+Import
+
+The ID is `<job_template_id>:<credential_id>`.
+
+```shell
+terraform import awx_job_template_credential.baseconfig 12:34
+```
+
 */
 package awx
 
 import (
 	"context"
 	"fmt"
-	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -28,6 +34,14 @@ func resourceJobTemplateCredentials() *schema.Resource {
 		CreateContext: resourceJobTemplateCredentialsCreate,
 		DeleteContext: resourceJobTemplateCredentialsDelete,
 		ReadContext:   resourceJobTemplateCredentialsRead,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCompositeID("job_template_id", "credential_id"),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			compositeIDStateUpgrader("job_template_id", "credential_id"),
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -47,7 +61,7 @@ func resourceJobTemplateCredentials() *schema.Resource {
 
 func resourceJobTemplateCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.JobTemplateService
 	jobTemplateID := d.Get("job_template_id").(int)
 	_, err := awxService.GetJobTemplateByID(jobTemplateID, make(map[string]string))
@@ -55,26 +69,27 @@ func resourceJobTemplateCredentialsCreate(ctx context.Context, d *schema.Resourc
 		return buildDiagNotFoundFail("job template", jobTemplateID, err)
 	}
 
-	result, err := awxService.AssociateCredentials(jobTemplateID, map[string]interface{}{
-		"id": d.Get("credential_id").(int),
+	credentialID := d.Get("credential_id").(int)
+	_, err = awxService.AssociateCredentials(jobTemplateID, map[string]interface{}{
+		"id": credentialID,
 	}, map[string]string{})
 
 	if err != nil {
 		return buildDiagnosticsMessage("Create: JobTemplate not AssociateCredentials", "Fail to add credentials with Id %v, for Template ID %v, got error: %s", d.Get("credential_id").(int), jobTemplateID, err.Error())
 	}
 
-	d.SetId(strconv.Itoa(result.ID))
+	d.SetId(buildCompositeID(jobTemplateID, credentialID))
 	return diags
 }
 
 func resourceJobTemplateCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/credentials/", d.Get("job_template_id").(int))
+	return readAssociation(d, m, "JobTemplate Credential", endpoint, "credential_id")
 }
 
 func resourceJobTemplateCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.JobTemplateService
 	jobTemplateID := d.Get("job_template_id").(int)
 	res, err := awxService.GetJobTemplateByID(jobTemplateID, make(map[string]string))
@@ -0,0 +1,69 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceJobTemplateCredential(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml"})
+	credentialID := server.Add("credentials", map[string]interface{}{"name": "machine", "credential_type": 1, "organization": orgID})
+	config := testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template_credential" "test" {
+  job_template_id = %d
+  credential_id = %d
+}
+`, jobTemplateID, credentialID))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if associated := server.Associated("job_templates", jobTemplateID, "credentials"); len(associated) != 0 {
+				return fmt.Errorf("credentials of job_templates %d are still %v", jobTemplateID, associated)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template_credential.test", "id", fmt.Sprintf("%d:%d", jobTemplateID, credentialID)),
+					func(*terraform.State) error {
+						if associated := server.Associated("job_templates", jobTemplateID, "credentials"); fmt.Sprint(associated) != fmt.Sprint([]int{credentialID}) {
+							return fmt.Errorf("credentials of job_templates %d are %v", jobTemplateID, associated)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "awx_job_template_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_job_template_credential.test",
+				ImportState:       true,
+				ImportStateId:     "job++org:machine++Machine+ssh++org",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					server.Disassociate("job_templates", jobTemplateID, "credentials", credentialID)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+			},
+		},
+	})
+}
@@ -36,8 +36,8 @@ import (
 	"time"
 
 	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -70,10 +70,12 @@ func resourceJobTemplateLaunch() *schema.Resource {
 				ForceNew:    true,
 			},
 			"extra_vars": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Override job template variables. Only JSON content is supported yet.",
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Override job template variables, in JSON or YAML.",
+				ForceNew:         true,
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
 			},
 			"wait_for_completion": {
 				Type:        schema.TypeBool,
@@ -84,29 +86,22 @@ func resourceJobTemplateLaunch() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-	}
-}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceJobTemplateLaunchImport,
+		},
 
-func statusInstanceState(ctx context.Context, svc *awx.JobService, id int) retry.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		output, err := svc.GetJob(id, map[string]string{})
-		return output, output.Status, err
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
 func jobTemplateLaunchWait(ctx context.Context, svc *awx.JobService, job *awx.JobLaunch, timeout time.Duration) error {
-
-	stateConf := &retry.StateChangeConf{
-		Pending:    []string{"new", "pending", "waiting", "running"},
-		Target:     []string{"successful"},
-		Refresh:    statusInstanceState(ctx, svc, job.ID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+	getJob := func(id int) (*awx.Job, error) {
+		return svc.GetJob(id, map[string]string{})
 	}
-
-	_, err := stateConf.WaitForStateContext(ctx)
-
+	_, err := waitForJob(ctx, jobStatusFunc(getJob, job.ID), timeout, "successful")
 	return err
 }
 
@@ -117,9 +112,27 @@ type JobTemplateLaunchData struct {
 	ExtraVars map[string]interface{} `json:"extra_vars,omitempty"`
 }
 
+// resourceJobTemplateLaunchImport adopts a job already launched, the ID being
+// the job ID. Only the job template is read back: the job records the limit
+// and inventory of the template as well as the launch overrides, and setting
+// them would plan a new launch whenever they are not in the configuration.
+func resourceJobTemplateLaunchImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*awxClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("unexpected ID %q, expected the numeric job id", d.Id())
+	}
+	job, err := client.JobService.GetJob(id, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	d.Set("job_template_id", job.JobTemplate)
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.JobTemplateService
 	awxJobService := client.JobService
 
@@ -129,15 +142,14 @@ func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData
 		return buildDiagNotFoundFail("job template", jobTemplateID, err)
 	}
 
-	var iExtraVars map[string]interface{}
-	err = json.Unmarshal([]byte(d.Get("extra_vars").(string)), &iExtraVars)
+	iExtraVars, err := decodeVariables(d.Get("extra_vars").(string))
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to decode extra_vars",
-			Detail:   fmt.Sprintf("JobTemplateLaunch with template ID %d, failed to decode extra_vars %s", d.Get("job_template_id").(int), err.Error()),
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Failed to decode extra_vars",
+			Detail:        fmt.Sprintf("JobTemplateLaunch with template ID %d, failed to decode extra_vars %s", d.Get("job_template_id").(int), err.Error()),
+			AttributePath: cty.GetAttrPath("extra_vars"),
 		})
-		return diags
 	}
 
 	data := JobTemplateLaunchData{
@@ -153,12 +165,11 @@ func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData
 	res, err := awxService.Launch(jobTemplateID, iData, map[string]string{})
 	if err != nil {
 		log.Printf("Failed to create Template Launch %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplateLaunch with template ID %d, failed to create %s", d.Get("job_template_id").(int), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create JobTemplate",
+			err,
+			"JobTemplateLaunch with template ID %d, failed to create", d.Get("job_template_id").(int),
+		)...)
 	}
 
 	// return resourceJobRead(ctx, d, m)
@@ -177,14 +188,26 @@ func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData
 	return diags
 }
 
+// resourceJobRead only checks the job still exists, a job being immutable
+// once launched.
 func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+	client := m.(*awxClient)
+	jobID, diags := convertStateIDToNummeric("Read Job", d)
+	if diags.HasError() {
+		return diags
+	}
+	if _, err := client.JobService.GetJob(jobID, map[string]string{}); err != nil {
+		if removeFromStateIfNotFound(d, "job", err) {
+			return nil
+		}
+		return buildDiagNotFoundFail("job", jobID, err)
+	}
 	return diags
 }
 
 func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.JobService
 	jobID, diags := convertStateIDToNummeric("Delete Job", d)
 	_, err := awxService.GetJob(jobID, map[string]string{})
@@ -0,0 +1,65 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceJobTemplateLaunch(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{
+		"name": "job", "project": projectID, "playbook": "site.yml", "inventory": inventoryID,
+		"ask_limit_on_launch": true, "ask_variables_on_launch": true,
+	})
+	config := func(limit string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template_launch" "test" {
+  job_template_id     = %d
+  limit               = %q
+  wait_for_completion = true
+
+  extra_vars = <<-YAML
+    foo: bar
+    nested:
+      key: value
+  YAML
+}
+`, jobTemplateID, limit))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("web"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "jobs", "awx_job_template_launch.test"),
+					testCheckField(server, "jobs", "awx_job_template_launch.test", "limit", "web"),
+					testCheckField(server, "jobs", "awx_job_template_launch.test", "status", "successful"),
+					testCheckField(server, "jobs", "awx_job_template_launch.test", "extra_vars", `{"foo":"bar","nested":{"key":"value"}}`),
+				),
+			},
+			{
+				ResourceName:            "awx_job_template_launch.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"limit", "inventory", "extra_vars", "wait_for_completion"},
+			},
+			{
+				Config: config("db"),
+				Check:  testCheckField(server, "jobs", "awx_job_template_launch.test", "limit", "db"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "jobs", "limit", "db"),
+				Config:             config("db"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
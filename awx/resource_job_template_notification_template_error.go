@@ -10,6 +10,14 @@ resource "awx_job_template_notification_template_error" "baseconfig" {
 }
 ```
 
+Import
+
+The ID is `<job_template_id>:<notification_template_id>`.
+
+```shell
+terraform import awx_job_template_notification_template_error.baseconfig 12:5
+```
+
 */
 package awx
 
@@ -21,7 +29,15 @@ func resourceJobTemplateNotificationTemplateError() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateNotificationTemplateCreateForType("error"),
 		DeleteContext: resourceJobTemplateNotificationTemplateDeleteForType("error"),
-		ReadContext:   resourceJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceJobTemplateNotificationTemplateReadForType("error"),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCompositeID("job_template_id", "notification_template_id"),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			compositeIDStateUpgrader("job_template_id", "notification_template_id"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
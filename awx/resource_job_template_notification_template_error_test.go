@@ -0,0 +1,69 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceJobTemplateNotificationTemplateError(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml"})
+	notificationTemplateID := server.Add("notification_templates", map[string]interface{}{"name": "notify", "organization": orgID, "notification_type": "slack"})
+	config := testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template_notification_template_error" "test" {
+  job_template_id = %d
+  notification_template_id = %d
+}
+`, jobTemplateID, notificationTemplateID))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if associated := server.Associated("job_templates", jobTemplateID, "notification_templates_error"); len(associated) != 0 {
+				return fmt.Errorf("notification_templates_error of job_templates %d are still %v", jobTemplateID, associated)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template_notification_template_error.test", "id", fmt.Sprintf("%d:%d", jobTemplateID, notificationTemplateID)),
+					func(*terraform.State) error {
+						if associated := server.Associated("job_templates", jobTemplateID, "notification_templates_error"); fmt.Sprint(associated) != fmt.Sprint([]int{notificationTemplateID}) {
+							return fmt.Errorf("notification_templates_error of job_templates %d are %v", jobTemplateID, associated)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "awx_job_template_notification_template_error.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_job_template_notification_template_error.test",
+				ImportState:       true,
+				ImportStateId:     "job++org:notify++org",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					server.Disassociate("job_templates", jobTemplateID, "notification_templates_error", notificationTemplateID)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+			},
+		},
+	})
+}
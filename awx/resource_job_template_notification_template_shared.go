@@ -2,7 +2,7 @@ package awx
 
 import (
 	"context"
-	"strconv"
+	"fmt"
 
 	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -36,7 +36,7 @@ func getResourceJobTemplateNotificationTemplateDisassociateFuncForType(client *a
 func resourceJobTemplateNotificationTemplateCreateForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		var diags diag.Diagnostics
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		awxJobTemplateService := client.JobTemplateService
 		jobTemplateID := d.Get("job_template_id").(int)
 		_, err := awxJobTemplateService.GetJobTemplateByID(jobTemplateID, make(map[string]string))
@@ -51,25 +51,27 @@ func resourceJobTemplateNotificationTemplateCreateForType(typ string) func(ctx c
 			return buildDiagnosticsMessage("Create: JobTemplate not AssociateJobTemplateNotificationTemplates", "Fail to find association function for notification_template type %s", typ)
 		}
 
-		result, err := associationFunc(jobTemplateID, notificationTemplateID)
+		_, err = associationFunc(jobTemplateID, notificationTemplateID)
 		if err != nil {
 			return buildDiagnosticsMessage("Create: JobTemplate not AssociateJobTemplateNotificationTemplates", "Fail to associate notification_template credentials with ID %v, for job_template ID %v, got error: %s", notificationTemplateID, jobTemplateID, err.Error())
 		}
 
-		d.SetId(strconv.Itoa(result.ID))
+		d.SetId(buildCompositeID(jobTemplateID, notificationTemplateID))
 		return diags
 	}
 }
 
-func resourceJobTemplateNotificationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+func resourceJobTemplateNotificationTemplateReadForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		endpoint := fmt.Sprintf("/api/v2/job_templates/%d/notification_templates_%s/", d.Get("job_template_id").(int), typ)
+		return readAssociation(d, m, "JobTemplate NotificationTemplate", endpoint, "notification_template_id")
+	}
 }
 
 func resourceJobTemplateNotificationTemplateDeleteForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		var diags diag.Diagnostics
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		awxJobTemplateService := client.JobTemplateService
 		jobTemplateID := d.Get("job_template_id").(int)
 		_, err := awxJobTemplateService.GetJobTemplateByID(jobTemplateID, make(map[string]string))
@@ -10,6 +10,14 @@ resource "awx_job_template_notification_template_started" "baseconfig" {
 }
 ```
 
+Import
+
+The ID is `<job_template_id>:<notification_template_id>`.
+
+```shell
+terraform import awx_job_template_notification_template_started.baseconfig 12:5
+```
+
 */
 package awx
 
@@ -21,7 +29,15 @@ func resourceJobTemplateNotificationTemplateStarted() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateNotificationTemplateCreateForType("started"),
 		DeleteContext: resourceJobTemplateNotificationTemplateDeleteForType("started"),
-		ReadContext:   resourceJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceJobTemplateNotificationTemplateReadForType("started"),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCompositeID("job_template_id", "notification_template_id"),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			compositeIDStateUpgrader("job_template_id", "notification_template_id"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
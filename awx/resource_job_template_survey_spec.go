@@ -0,0 +1,53 @@
+/*
+*TBD*
+
+Example Usage
+
+```hcl
+resource "awx_job_template_survey_spec" "baseconfig" {
+  job_template_id = awx_job_template.baseconfig.id
+  name            = "baseconfig"
+
+  question {
+    variable      = "environment"
+    question_name = "Environment"
+    type          = "multiplechoice"
+    choices       = ["staging", "production"]
+    default       = "staging"
+    required      = true
+  }
+
+  question {
+    variable      = "vault_password"
+    question_name = "Vault password"
+    type          = "password"
+    min           = 8
+    max           = 64
+  }
+}
+```
+
+The survey is only prompted for when `survey_enabled` is set on the job template.
+
+Import
+
+Surveys can be imported by the ID or the AWX named URL of their job template, `<name>++<organization>`.
+
+```shell
+terraform import awx_job_template_survey_spec.baseconfig 'baseconfig++Default'
+```
+
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func newJobTemplateSurveySpecResource() resource.Resource {
+	return &surveySpecResource{
+		typeSuffix:        "_job_template_survey_spec",
+		collection:        "job_templates",
+		templateAttribute: "job_template_id",
+	}
+}
@@ -0,0 +1,181 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/denouche/terraform-provider-awx/awx/internal/fakeawx"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceJobTemplateSurveySpec(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml", "organization": orgID})
+	config := func(environment string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template_survey_spec" "test" {
+  job_template_id = %d
+  name            = "test"
+
+  question {
+    variable      = "environment"
+    question_name = %q
+    type          = "multiplechoice"
+    choices       = ["staging", "production"]
+    default       = "staging"
+    required      = true
+  }
+
+  question {
+    variable      = "vault_password"
+    question_name = "Vault password"
+    type          = "password"
+    default       = "secret"
+    min           = 4
+  }
+
+  question {
+    variable      = "batch"
+    question_name = "Batch size"
+    type          = "integer"
+    default       = "5"
+    min           = 1
+    max           = 10
+  }
+}
+`, jobTemplateID, environment))
+	}
+	invalid := func(question string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template_survey_spec" "test" {
+  job_template_id = %d
+
+  question {
+    variable      = "first"
+    question_name = "First"
+    type          = "text"
+  }
+
+  question {
+    question_name = "Second"
+    %s
+  }
+}
+`, jobTemplateID, question))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckSurveyDestroyed(server, "job_templates", jobTemplateID),
+		Steps: []resource.TestStep{
+			{
+				Config:      invalid(`variable = "first"` + "\n" + `type = "text"`),
+				ExpectError: regexp.MustCompile(`Duplicate survey variable`),
+			},
+			{
+				Config:      invalid(`variable = "second"` + "\n" + `type = "multiselect"` + "\n" + `choices = ["a", "b"]` + "\n" + `default = "a\nc"`),
+				ExpectError: regexp.MustCompile(`The default "c" is not one of the choices`),
+			},
+			{
+				Config:      invalid(`variable = "second"` + "\n" + `type = "text"` + "\n" + `choices = ["a"]`),
+				ExpectError: regexp.MustCompile(`Only multiplechoice and multiselect questions have choices`),
+			},
+			{
+				Config:      invalid(`variable = "second"` + "\n" + `type = "integer"` + "\n" + `default = "1.5"`),
+				ExpectError: regexp.MustCompile(`is not an integer`),
+			},
+			{
+				Config: config("Environment"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSurveyQuestion(server, "job_templates", "awx_job_template_survey_spec.test", 0, "choices", []interface{}{"staging", "production"}),
+					testCheckSurveyQuestion(server, "job_templates", "awx_job_template_survey_spec.test", 1, "default", "secret"),
+					testCheckSurveyQuestion(server, "job_templates", "awx_job_template_survey_spec.test", 2, "default", 5),
+					resource.TestCheckResourceAttr("awx_job_template_survey_spec.test", "id", strconv.Itoa(jobTemplateID)),
+					resource.TestCheckResourceAttr("awx_job_template_survey_spec.test", "question.1.default", "secret"),
+				),
+			},
+			{
+				ResourceName:      "awx_job_template_survey_spec.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// AWX never gives back the default of password questions
+				ImportStateVerifyIgnore: []string{"question.1.default"},
+			},
+			{
+				ResourceName:            "awx_job_template_survey_spec.test",
+				ImportState:             true,
+				ImportStateId:           "job++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"question.1.default"},
+			},
+			{
+				Config: config("Target environment"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSurveyQuestion(server, "job_templates", "awx_job_template_survey_spec.test", 0, "question_name", "Target environment"),
+					testCheckSurveyQuestion(server, "job_templates", "awx_job_template_survey_spec.test", 1, "default", "secret"),
+				),
+			},
+			{
+				PreConfig: func() {
+					spec := server.Survey("job_templates", jobTemplateID)
+					spec["spec"] = spec["spec"].([]interface{})[:1]
+					server.SetSurvey("job_templates", jobTemplateID, spec)
+				},
+				Config:             config("Target environment"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("Target environment"),
+				Check:  testCheckSurveyQuestion(server, "job_templates", "awx_job_template_survey_spec.test", 2, "max", 10),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "job_templates", "name", "job"),
+				Config:             config("Target environment"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testCheckSurveyQuestion checks a field of a question of the survey of a
+// resource.
+func testCheckSurveyQuestion(server *fakeawx.Server, collection, name string, index int, field string, expected interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", name)
+		}
+		id, _ := strconv.Atoi(rs.Primary.ID)
+		spec := server.Survey(collection, id)
+		if spec == nil {
+			return fmt.Errorf("%s %d of %s has no survey", collection, id, name)
+		}
+		questions := spec["spec"].([]interface{})
+		if index >= len(questions) {
+			return fmt.Errorf("the survey of %s %d has %d questions, expected question %d", collection, id, len(questions), index)
+		}
+		value := questions[index].(map[string]interface{})[field]
+		if fmt.Sprint(value) != fmt.Sprint(expected) {
+			return fmt.Errorf("%s of question %d of the survey of %s %d is %v, expected %v", field, index, collection, id, value, expected)
+		}
+		return nil
+	}
+}
+
+// testCheckSurveyDestroyed checks the template id of collection has no survey
+// left.
+func testCheckSurveyDestroyed(server *fakeawx.Server, collection string, id int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if server.Survey(collection, id) != nil {
+			return fmt.Errorf("%s %d still has a survey", collection, id)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,302 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/denouche/terraform-provider-awx/awx/internal/fakeawx"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceJobTemplate(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template" "test" {
+  name         = "test"
+  description  = %q
+  job_type     = "run"
+  inventory_id = %d
+  project_id   = %d
+  playbook     = "site.yml"
+  limit        = "web"
+  extra_vars   = "foo: bar"
+}
+`, description, inventoryID, projectID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "job_templates", "awx_job_template"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "job_templates", "awx_job_template.test"),
+					testCheckField(server, "job_templates", "awx_job_template.test", "project", projectID),
+					testCheckField(server, "job_templates", "awx_job_template.test", "organization", orgID),
+					resource.TestCheckResourceAttr("awx_job_template.test", "limit", "web"),
+				),
+			},
+			{
+				ResourceName:      "awx_job_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_job_template.test",
+				ImportState:       true,
+				ImportStateId:     "test++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "job_templates", "awx_job_template.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "job_templates", "name", "test", map[string]interface{}{"limit": "db"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "job_templates", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceJobTemplateExtraVarsMap(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	config := func(replicas string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template" "test" {
+  name         = "test"
+  job_type     = "run"
+  inventory_id = %d
+  project_id   = %d
+  playbook     = "site.yml"
+
+  extra_vars_map = {
+    release  = "stable"
+    replicas = %q
+    ports    = "[80, 443]"
+  }
+}
+`, inventoryID, projectID, replicas))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "job_templates", "awx_job_template"),
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template" "test" {
+  name           = "test"
+  job_type       = "run"
+  inventory_id   = %d
+  project_id     = %d
+  extra_vars     = "foo: bar"
+  extra_vars_map = { foo = "bar" }
+}
+`, inventoryID, projectID)),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: config("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckField(server, "job_templates", "awx_job_template.test", "extra_vars", `{"ports":[80,443],"release":"stable","replicas":2}`),
+					resource.TestCheckResourceAttr("awx_job_template.test", "extra_vars", ""),
+					resource.TestCheckResourceAttr("awx_job_template.test", "extra_vars_map.ports", "[80, 443]"),
+				),
+			},
+			{
+				Config: config("3"),
+				Check:  testCheckField(server, "job_templates", "awx_job_template.test", "extra_vars", `{"ports":[80,443],"release":"stable","replicas":3}`),
+			},
+			{
+				PreConfig: testUpdateObject(t, server, "job_templates", "name", "test", map[string]interface{}{
+					"extra_vars": `{"ports":[80,443],"release":"edge","replicas":3}`,
+				}),
+				Config:             config("3"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("3"),
+				Check:  testCheckField(server, "job_templates", "awx_job_template.test", "extra_vars", `{"ports":[80,443],"release":"stable","replicas":3}`),
+			},
+		},
+	})
+}
+
+func TestResourceJobTemplateAllAttributes(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	eeID := server.Add("execution_environments", map[string]interface{}{"name": "ee", "image": "quay.io/ansible/awx-ee:latest"})
+	credentialID := server.Add("credentials", map[string]interface{}{"name": "token", "credential_type": 1, "organization": orgID})
+	config := testProviderConfig(server, fmt.Sprintf(`
+resource "awx_job_template" "test" {
+  name                  = "test"
+  job_type              = "run"
+  inventory_id          = %d
+  project_id            = %d
+  playbook              = "site.yml"
+  execution_environment = %d
+  timeout               = 600
+  allow_simultaneous    = true
+  scm_branch            = "release"
+  job_slice_count       = 3
+  webhook_service       = "gitlab"
+  webhook_credential    = %d
+
+  prevent_instance_group_fallback     = true
+  ask_diff_mode_on_launch             = true
+  ask_verbosity_on_launch             = true
+  ask_inventory_on_launch             = true
+  ask_scm_branch_on_launch            = true
+  ask_execution_environment_on_launch = true
+  ask_labels_on_launch                = true
+  ask_forks_on_launch                 = true
+  ask_job_slice_count_on_launch       = true
+  ask_timeout_on_launch               = true
+  ask_instance_groups_on_launch       = true
+}
+`, inventoryID, projectID, eeID, credentialID))
+	configured := map[string]interface{}{
+		"execution_environment":               eeID,
+		"timeout":                             600,
+		"allow_simultaneous":                  true,
+		"scm_branch":                          "release",
+		"job_slice_count":                     3,
+		"webhook_service":                     "gitlab",
+		"webhook_credential":                  credentialID,
+		"prevent_instance_group_fallback":     true,
+		"ask_diff_mode_on_launch":             true,
+		"ask_verbosity_on_launch":             true,
+		"ask_inventory_on_launch":             true,
+		"ask_scm_branch_on_launch":            true,
+		"ask_execution_environment_on_launch": true,
+		"ask_labels_on_launch":                true,
+		"ask_forks_on_launch":                 true,
+		"ask_job_slice_count_on_launch":       true,
+		"ask_timeout_on_launch":               true,
+		"ask_instance_groups_on_launch":       true,
+	}
+	drifted := map[string]interface{}{
+		"execution_environment": nil,
+		"timeout":               0,
+		"scm_branch":            "main",
+		"job_slice_count":       1,
+		"webhook_service":       "github",
+		"webhook_credential":    nil,
+	}
+
+	var checks []resource.TestCheckFunc
+	for field, value := range configured {
+		checks = append(checks, testCheckField(server, "job_templates", "awx_job_template.test", field, value))
+	}
+	steps := []resource.TestStep{
+		{
+			PreConfig: func() {
+				server.SetVersion("21.0.0")
+			},
+			Config:      config,
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`ask_labels_on_launch is not supported by the connected controller`),
+		},
+		{
+			PreConfig: func() {
+				server.SetVersion(fakeawx.DefaultVersion)
+			},
+			Config: config,
+			Check:  resource.ComposeTestCheckFunc(checks...),
+		},
+		{
+			ResourceName:      "awx_job_template.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}
+	// a change of any of the fields outside of Terraform shows in the plan
+	for field := range configured {
+		value, ok := drifted[field]
+		if !ok {
+			value = false
+		}
+		steps = append(steps,
+			resource.TestStep{
+				PreConfig:          testUpdateObject(t, server, "job_templates", "name", "test", map[string]interface{}{field: value}),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			resource.TestStep{
+				Config: config,
+				Check:  testCheckField(server, "job_templates", "awx_job_template.test", field, configured[field]),
+			},
+		)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "job_templates", "awx_job_template"),
+		Steps:                    steps,
+	})
+}
+
+func TestResourceJobTemplateAdoptExisting(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	previousProjectID := server.Add("projects", map[string]interface{}{"name": "previous", "organization": orgID})
+	id := server.Add("job_templates", map[string]interface{}{"name": "test", "project": previousProjectID, "playbook": "site.yml"})
+	config := fmt.Sprintf(`
+provider "awx" {
+  hostname       = %q
+  token          = "test"
+  max_retries    = 0
+  adopt_existing = true
+}
+
+resource "awx_job_template" "test" {
+  name         = "test"
+  job_type     = "run"
+  inventory_id = %d
+  project_id   = %d
+  playbook     = "site.yml"
+}
+`, server.URL, inventoryID, projectID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "job_templates", "awx_job_template"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.test", "id", strconv.Itoa(id)),
+					testCheckField(server, "job_templates", "awx_job_template.test", "project", projectID),
+				),
+			},
+		},
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -37,58 +38,77 @@ func resourceNotificationTemplate() *schema.Resource {
 				Required: true,
 			},
 			"organization_id": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Required: true,
 			},
 			"notification_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "One of: awssns, email, grafana, irc, mattermost, pagerduty, rocketchat, slack, twilio, webhook",
+				ValidateDiagFunc: validateNotificationType,
 			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
 			"notification_configuration": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateJSONObject,
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			numericIDStateUpgrader(0, "organization_id"),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("notification_templates"),
+		},
 	}
 }
 
 func resourceNotificationTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "notification_templates", map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceNotificationTemplateUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.NotificationTemplatesService
 
 	notificationConfigurationStr := d.Get("notification_configuration").(string)
 	notificationConfigurationMap := make(map[string]interface{})
 	err := json.Unmarshal([]byte(notificationConfigurationStr), &notificationConfigurationMap)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create NotificationTemplate",
-			Detail:   fmt.Sprintf("error while unmarshal notification_configuration: %s", err.Error()),
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Unable to create NotificationTemplate",
+			Detail:        fmt.Sprintf("error while unmarshal notification_configuration: %s", err.Error()),
+			AttributePath: cty.GetAttrPath("notification_configuration"),
 		})
-		return diags
 	}
 
 	result, err := awxService.Create(map[string]interface{}{
 		"name":                       d.Get("name").(string),
 		"description":                d.Get("description").(string),
-		"organization":               d.Get("organization_id").(string),
+		"organization":               d.Get("organization_id").(int),
 		"notification_type":          d.Get("notification_type").(string),
 		"notification_configuration": notificationConfigurationMap,
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create notification_template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create NotificationTemplate",
-			Detail:   fmt.Sprintf("NotificationTemplate failed to create %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create NotificationTemplate",
+			err,
+			"NotificationTemplate failed to create",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -97,7 +117,7 @@ func resourceNotificationTemplateCreate(ctx context.Context, d *schema.ResourceD
 
 func resourceNotificationTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.NotificationTemplatesService
 	id, diags := convertStateIDToNummeric("Update NotificationTemplate", d)
 	if diags.HasError() {
@@ -114,28 +134,27 @@ func resourceNotificationTemplateUpdate(ctx context.Context, d *schema.ResourceD
 	notificationConfigurationMap := make(map[string]interface{})
 	err = json.Unmarshal([]byte(notificationConfigurationStr), &notificationConfigurationMap)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create NotificationTemplate",
-			Detail:   fmt.Sprintf("error while unmarshal notification_configuration: %s", err.Error()),
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Unable to update NotificationTemplate",
+			Detail:        fmt.Sprintf("error while unmarshal notification_configuration: %s", err.Error()),
+			AttributePath: cty.GetAttrPath("notification_configuration"),
 		})
-		return diags
 	}
 
 	_, err = awxService.Update(id, map[string]interface{}{
 		"name":                       d.Get("name").(string),
 		"description":                d.Get("description").(string),
-		"organization":               d.Get("organization_id").(string),
+		"organization":               d.Get("organization_id").(int),
 		"notification_type":          d.Get("notification_type").(string),
 		"notification_configuration": notificationConfigurationMap,
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update NotificationTemplate",
-			Detail:   fmt.Sprintf("notification_template with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to update NotificationTemplate",
+			err,
+			"notification_template with name %s failed to update", d.Get("name").(string),
+		)...)
 	}
 
 	return resourceNotificationTemplateRead(ctx, d, m)
@@ -143,24 +162,33 @@ func resourceNotificationTemplateUpdate(ctx context.Context, d *schema.ResourceD
 
 func resourceNotificationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.NotificationTemplatesService
+	client := m.(*awxClient)
 	id, diags := convertStateIDToNummeric("Read notification_template", d)
 	if diags.HasError() {
 		return diags
 	}
 
-	res, err := awxService.GetByID(id, make(map[string]string))
+	// goawx expects the organization as a string and drops it
+	res := new(struct {
+		awx.NotificationTemplate
+		Organization int `json:"organization"`
+	})
+	err := client.getJSON(fmt.Sprintf("/api/v2/notification_templates/%d/", id), res, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "notification_template", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("notification_template", id, err)
 
 	}
-	d = setNotificationTemplateResourceData(d, res)
+	d = setNotificationTemplateResourceData(d, &res.NotificationTemplate)
+	d.Set("organization_id", res.Organization)
+	setJSONOnImport(d, "notification_configuration", res.NotificationConfiguration)
 	return nil
 }
 
 func resourceNotificationTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.NotificationTemplatesService
 	id, diags := convertStateIDToNummeric(diagElementHostTitle, d)
 	if diags.HasError() {
@@ -180,9 +208,7 @@ func resourceNotificationTemplateDelete(ctx context.Context, d *schema.ResourceD
 func setNotificationTemplateResourceData(d *schema.ResourceData, r *awx.NotificationTemplate) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
-	d.Set("organization", r.Organization)
 	d.Set("notification_type", r.NotificationType)
-	d.Set("notification_configuration", r.NotificationConfiguration)
 	d.SetId(strconv.Itoa(r.ID))
 	return d
 }
@@ -0,0 +1,71 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceNotificationTemplate(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_notification_template" "test" {
+  name              = "test"
+  description       = %q
+  organization_id   = %d
+  notification_type = "slack"
+  notification_configuration = jsonencode({
+    channels = ["#general"]
+    token    = "token"
+  })
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "notification_templates", "awx_notification_template"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "notification_templates", "awx_notification_template.test"),
+					testCheckField(server, "notification_templates", "awx_notification_template.test", "notification_type", "slack"),
+				),
+			},
+			{
+				ResourceName:      "awx_notification_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_notification_template.test",
+				ImportState:       true,
+				ImportStateId:     "test++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "notification_templates", "awx_notification_template.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "notification_templates", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "notification_templates", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
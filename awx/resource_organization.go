@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -29,6 +30,7 @@ func resourceOrganization() *schema.Resource {
 		ReadContext:   resourceOrganizationsRead,
 		UpdateContext: resourceOrganizationsUpdate,
 		DeleteContext: resourceOrganizationsDelete,
+		CustomizeDiff: customizeDiffVersionedAttributes("organization", organizationVersionedAttributes...),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -60,46 +62,64 @@ func resourceOrganization() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("organizations"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
-		//
-		//Timeouts: &schema.ResourceTimeout{
-		//	Create: schema.DefaultTimeout(1 * time.Minute),
-		//	Update: schema.DefaultTimeout(1 * time.Minute),
-		//	Delete: schema.DefaultTimeout(1 * time.Minute),
-		//},
 	}
 }
 
+// organizationVersionedAttributes are the organization attributes only some
+// controller versions support.
+var organizationVersionedAttributes = []versionedAttribute{attributeCustomVirtualenv, attributeDefaultEnvironment}
+
 func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "organizations", map[string]interface{}{
+		"name": d.Get("name").(string),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceOrganizationsUpdate(ctx, d, m)
+	}
+
+	diags := checkVersionedAttributes(d, m, "organization", organizationVersionedAttributes...)
+	if diags.HasError() {
+		return diags
+	}
+	client := m.(*awxClient)
 	awxService := client.OrganizationsService
 
 	result, err := awxService.CreateOrganization(map[string]interface{}{
 		"name":                d.Get("name").(string),
 		"description":         d.Get("description").(string),
 		"max_hosts":           d.Get("max_hosts").(int),
-		"custom_virtualenv":   d.Get("description").(string),
-		"default_environment": d.Get("default_environment").(int),
+		"custom_virtualenv":   StringpOrNil(d.Get("custom_virtualenv").(string)),
+		"default_environment": IntpOrNil(d.Get("default_environment").(int)),
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Organization %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Organizations",
-			Detail:   fmt.Sprintf("Organizations with name %s in the project id %d, failed to create %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create Organizations",
+			err,
+			"Organizations with name %s, failed to create", d.Get("name").(string),
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
-	return resourceOrganizationsRead(ctx, d, m)
+	return append(diags, resourceOrganizationsRead(ctx, d, m)...)
 }
 
 func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	versionDiags := checkVersionedAttributes(d, m, "organization", organizationVersionedAttributes...)
+	if versionDiags.HasError() {
+		return versionDiags
+	}
+	client := m.(*awxClient)
 	awxService := client.OrganizationsService
 	id, diags := convertStateIDToNummeric("Update Organizations", d)
 	if diags.HasError() {
@@ -117,43 +137,50 @@ func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m
 		"name":                d.Get("name").(string),
 		"description":         d.Get("description").(string),
 		"max_hosts":           d.Get("max_hosts").(int),
-		"custom_virtualenv":   d.Get("description").(string),
-		"default_environment": d.Get("default_environment").(int),
+		"custom_virtualenv":   StringpOrNil(d.Get("custom_virtualenv").(string)),
+		"default_environment": IntpOrNil(d.Get("default_environment").(int)),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update Organizations",
-			Detail:   fmt.Sprintf("Organizations with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to update Organizations",
+			err,
+			"Organizations with name %s failed to update", d.Get("name").(string),
+		)...)
 	}
 
-	return resourceOrganizationsRead(ctx, d, m)
+	return append(versionDiags, resourceOrganizationsRead(ctx, d, m)...)
 }
 
 func resourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.OrganizationsService
+	client := m.(*awxClient)
 	id, diags := convertStateIDToNummeric("Read Organizations", d)
 	if diags.HasError() {
 		return diags
 	}
 
-	res, err := awxService.GetOrganizationsByID(id, make(map[string]string))
+	// goawx does not read the default execution environment
+	res := new(struct {
+		awx.Organization
+		DefaultEnvironment int `json:"default_environment"`
+	})
+	err := client.getJSON(fmt.Sprintf("/api/v2/organizations/%d/", id), res, map[string]string{})
 	if err != nil {
+		if removeFromStateIfNotFound(d, "Organization", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("Organization", id, err)
 
 	}
-	d = setOrganizationsResourceData(d, res)
+	d = setOrganizationsResourceData(d, &res.Organization)
+	d.Set("default_environment", res.DefaultEnvironment)
 	return nil
 }
 
 func resourceOrganizationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	digMessagePart := "Organization"
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.OrganizationsService
 	id, diags := convertStateIDToNummeric("Delete Organization", d)
 	if diags.HasError() {
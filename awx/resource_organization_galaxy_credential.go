@@ -10,15 +10,21 @@ resource "awx_organization_galaxy_credential" "baseconfig" {
 }
 ```
 
+Import
+
+The ID is `<organization_id>:<credential_id>`.
+
+```shell
+terraform import awx_organization_galaxy_credential.baseconfig 1:34
+```
+
 */
 package awx
 
 import (
 	"context"
 	"fmt"
-	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -28,6 +34,14 @@ func resourceOrganizationsGalaxyCredentials() *schema.Resource {
 		CreateContext: resourceOrganizationsGalaxyCredentialsCreate,
 		DeleteContext: resourceOrganizationsGalaxyCredentialsDelete,
 		ReadContext:   resourceOrganizationsGalaxyCredentialsRead,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCompositeID("organization_id", "credential_id"),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			compositeIDStateUpgrader("organization_id", "credential_id"),
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -47,7 +61,7 @@ func resourceOrganizationsGalaxyCredentials() *schema.Resource {
 
 func resourceOrganizationsGalaxyCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.OrganizationsService
 	OrganizationID := d.Get("organization_id").(int)
 	_, err := awxService.GetOrganizationsByID(OrganizationID, make(map[string]string))
@@ -55,26 +69,27 @@ func resourceOrganizationsGalaxyCredentialsCreate(ctx context.Context, d *schema
 		return buildDiagNotFoundFail("organization", OrganizationID, err)
 	}
 
-	result, err := awxService.AssociateGalaxyCredentials(OrganizationID, map[string]interface{}{
-		"id": d.Get("credential_id").(int),
+	credentialID := d.Get("credential_id").(int)
+	_, err = awxService.AssociateGalaxyCredentials(OrganizationID, map[string]interface{}{
+		"id": credentialID,
 	}, map[string]string{})
 
 	if err != nil {
 		return buildDiagnosticsMessage("Create: Organization not AssociateGalaxyCredentials", "Fail to add Galaxy credentials with Id %v, for Organization ID %v, got error: %s", d.Get("credential_id").(int), OrganizationID, err.Error())
 	}
 
-	d.SetId(strconv.Itoa(result.ID))
+	d.SetId(buildCompositeID(OrganizationID, credentialID))
 	return diags
 }
 
 func resourceOrganizationsGalaxyCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	endpoint := fmt.Sprintf("/api/v2/organizations/%d/galaxy_credentials/", d.Get("organization_id").(int))
+	return readAssociation(d, m, "Organization Galaxy Credential", endpoint, "credential_id")
 }
 
 func resourceOrganizationsGalaxyCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.OrganizationsService
 	OrganizationID := d.Get("organization_id").(int)
 	res, err := awxService.GetOrganizationsByID(OrganizationID, make(map[string]string))
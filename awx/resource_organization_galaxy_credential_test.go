@@ -0,0 +1,67 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceOrganizationGalaxyCredential(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	credentialID := server.Add("credentials", map[string]interface{}{"name": "galaxy", "credential_type": 18, "organization": orgID})
+	config := testProviderConfig(server, fmt.Sprintf(`
+resource "awx_organization_galaxy_credential" "test" {
+  organization_id = %d
+  credential_id = %d
+}
+`, orgID, credentialID))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if associated := server.Associated("organizations", orgID, "galaxy_credentials"); len(associated) != 0 {
+				return fmt.Errorf("galaxy_credentials of organizations %d are still %v", orgID, associated)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_organization_galaxy_credential.test", "id", fmt.Sprintf("%d:%d", orgID, credentialID)),
+					func(*terraform.State) error {
+						if associated := server.Associated("organizations", orgID, "galaxy_credentials"); fmt.Sprint(associated) != fmt.Sprint([]int{credentialID}) {
+							return fmt.Errorf("galaxy_credentials of organizations %d are %v", orgID, associated)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "awx_organization_galaxy_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_organization_galaxy_credential.test",
+				ImportState:       true,
+				ImportStateId:     "org:galaxy++Ansible Galaxy/Automation Hub API Token+galaxy++org",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					server.Disassociate("organizations", orgID, "galaxy_credentials", credentialID)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+			},
+		},
+	})
+}
@@ -0,0 +1,102 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/denouche/terraform-provider-awx/awx/internal/fakeawx"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceOrganization(t *testing.T) {
+	server := newTestServer(t)
+	config := func(description string) string {
+		return testProviderConfig(server, `
+resource "awx_organization" "test" {
+  name        = "test"
+  description = "`+description+`"
+  max_hosts   = 10
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "organizations", "awx_organization"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "organizations", "awx_organization.test"),
+					testCheckField(server, "organizations", "awx_organization.test", "max_hosts", 10),
+					resource.TestCheckResourceAttr("awx_organization.test", "description", "created"),
+				),
+			},
+			{
+				ResourceName:      "awx_organization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_organization.test",
+				ImportState:       true,
+				ImportStateId:     "test",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "organizations", "awx_organization.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "organizations", "name", "test", map[string]interface{}{"max_hosts": 20}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "organizations", "awx_organization.test", "max_hosts", 10),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "organizations", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceOrganizationVersionedAttributes(t *testing.T) {
+	server := newTestServer(t)
+	eeID := server.Add("execution_environments", map[string]interface{}{"name": "ee", "image": "quay.io/ansible/awx-ee:latest"})
+	config := testProviderConfig(server, fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name                = "test"
+  default_environment = %d
+}
+`, eeID))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "organizations", "awx_organization"),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					server.SetVersion("17.0.0")
+				},
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`default_environment is not supported by the connected controller`),
+			},
+			{
+				PreConfig: func() {
+					server.SetVersion(fakeawx.DefaultVersion)
+				},
+				Config: config,
+				Check:  testCheckField(server, "organizations", "awx_organization.test", "default_environment", eeID),
+			},
+		},
+	})
+}
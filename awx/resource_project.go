@@ -62,9 +62,10 @@ func resourceProject() *schema.Resource {
 			},
 
 			"scm_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "One of \"\" (manual), git, hg, svn",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "One of \"\" (manual), git, hg, svn, insights, archive",
+				ValidateDiagFunc: validateSCMType,
 			},
 
 			"scm_url": {
@@ -117,7 +118,7 @@ func resourceProject() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("projects"),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -129,7 +130,17 @@ func resourceProject() *schema.Resource {
 }
 
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "projects", map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceProjectUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.ProjectService
 
 	orgID := d.Get("organization_id").(int)
@@ -143,7 +154,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		return buildDiagnosticsMessage("Create: Fail to find Project", "Fail to find Project %s Organization ID %v, %s", projectName, orgID, err.Error())
 	}
 	if len(res.Results) >= 1 {
-		return buildDiagnosticsMessage("Create: Always exist", "Project with name %s  already exists in the Organization ID %v", projectName, orgID)
+		return buildDiagnosticsMessage("Create: Always exist", "Project with name %s  already exists in the Organization ID %v, set adopt_existing in the provider to take it over", projectName, orgID)
 	}
 	credentials := ""
 	if d.Get("scm_credential_id").(int) > 0 {
@@ -166,7 +177,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		"allow_override":           d.Get("allow_override").(bool),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Create: Project not created", "Project with name %s  in the Organization ID %v not created, %s", projectName, orgID, err.Error())
+		return buildDiagAPIFail("Create: Project not created", err, "Project with name %s  in the Organization ID %v not created", projectName, orgID)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -174,7 +185,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ProjectService
 
 	id, diags := convertStateIDToNummeric("Update Project", d)
@@ -208,14 +219,14 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	_, err := awxService.UpdateProject(id, data, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Update: Fail To Update Project", "Fail to get Project with ID %v, got %s", id, err.Error())
+		return buildDiagAPIFail("Update: Fail To Update Project", err, "Fail to update Project with ID %v", id)
 	}
 	return resourceProjectRead(ctx, d, m)
 }
 
 func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ProjectService
 
 	id, diags := convertStateIDToNummeric("Read Project", d)
@@ -225,6 +236,9 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	res, err := awxService.GetProjectByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, "project", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("project", id, err)
 	}
 	d = setProjectResourceData(d, res)
@@ -234,10 +248,9 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	digMessagePart := "Project"
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ProjectService
 	var jobID int
-	var finished time.Time
 	id, diags := convertStateIDToNummeric("Delete Project", d)
 	if diags.HasError() {
 		return diags
@@ -264,11 +277,16 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 			)
 		}
 	}
-	// check if finished is 0
-	for finished.IsZero() {
-		prj, _ := client.ProjectUpdatesService.ProjectUpdateGet(jobID)
-		finished = prj.Finished
-		time.Sleep(1 * time.Second)
+	if jobID != 0 {
+		// the project can only be deleted once its update is done
+		refresh := jobStatusFunc(client.ProjectUpdatesService.ProjectUpdateGet, jobID)
+		if _, err = waitForJob(ctx, refresh, d.Timeout(schema.TimeoutDelete), jobFinishedStatuses...); err != nil {
+			return buildDiagnosticsMessage(
+				"Delete: Fail to wait for the project update",
+				"Fail to wait for the Job %v of Project with ID %v to finish, got %s",
+				jobID, id, err.Error(),
+			)
+		}
 	}
 
 	if _, err = awxService.DeleteProject(id); err != nil {
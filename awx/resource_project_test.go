@@ -0,0 +1,70 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceProject(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_project" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+  scm_type        = "git"
+  scm_url         = "https://github.com/ansible/ansible-tower-samples"
+  scm_branch      = "master"
+}
+`, description, orgID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "projects", "awx_project"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "projects", "awx_project.test"),
+					testCheckField(server, "projects", "awx_project.test", "scm_type", "git"),
+					resource.TestCheckResourceAttr("awx_project.test", "scm_branch", "master"),
+				),
+			},
+			{
+				ResourceName:      "awx_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_project.test",
+				ImportState:       true,
+				ImportStateId:     "test++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "projects", "awx_project.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "projects", "name", "test", map[string]interface{}{"scm_branch": "main"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "projects", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
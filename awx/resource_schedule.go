@@ -62,19 +62,37 @@ func resourceSchedule() *schema.Resource {
 				Optional: true,
 			},
 			"extra_data": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Extra data to be pass for the schedule (YAML format)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				Description:      "Extra data to be pass for the schedule (JSON or YAML format)",
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("schedules"),
+		},
 	}
 }
 
 func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "schedules", map[string]interface{}{
+		"name":                 d.Get("name").(string),
+		"unified_job_template": d.Get("unified_job_template_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceScheduleUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.ScheduleService
+	extraData, diags := getVariables(d, "extra_data")
+	if diags.HasError() {
+		return diags
+	}
 
 	result, err := awxService.Create(map[string]interface{}{
 		"name":                 d.Get("name").(string),
@@ -82,17 +100,16 @@ func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, m inter
 		"unified_job_template": d.Get("unified_job_template_id").(int),
 		"description":          d.Get("description").(string),
 		"enabled":              d.Get("enabled").(bool),
-		"inventory":            d.Get("inventory").(int),
-		"extra_data":           unmarshalYaml(d.Get("extra_data").(string)),
+		"inventory":            IntpOrNil(d.Get("inventory").(int)),
+		"extra_data":           extraData,
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Schedule %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Schedule",
-			Detail:   fmt.Sprintf("Schedule failed to create %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create Schedule",
+			err,
+			"Schedule failed to create",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -101,7 +118,7 @@ func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ScheduleService
 	id, diags := convertStateIDToNummeric("Update Schedule", d)
 	if diags.HasError() {
@@ -113,6 +130,10 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return buildDiagNotFoundFail("schedule", id, err)
 	}
+	extraData, diags := getVariables(d, "extra_data")
+	if diags.HasError() {
+		return diags
+	}
 
 	_, err = awxService.Update(id, map[string]interface{}{
 		"name":                 d.Get("name").(string),
@@ -120,16 +141,15 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		"unified_job_template": d.Get("unified_job_template_id").(int),
 		"description":          d.Get("description").(string),
 		"enabled":              d.Get("enabled").(bool),
-		"inventory":            d.Get("inventory").(int),
-		"extra_data":           unmarshalYaml(d.Get("extra_data").(string)),
+		"inventory":            IntpOrNil(d.Get("inventory").(int)),
+		"extra_data":           extraData,
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update Schedule",
-			Detail:   fmt.Sprintf("Schedule with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to update Schedule",
+			err,
+			"Schedule with name %s failed to update", d.Get("name").(string),
+		)...)
 	}
 
 	return resourceScheduleRead(ctx, d, m)
@@ -137,7 +157,7 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ScheduleService
 	id, diags := convertStateIDToNummeric("Read schedule", d)
 	if diags.HasError() {
@@ -146,6 +166,9 @@ func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 	res, err := awxService.GetByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, "schedule", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("schedule", id, err)
 
 	}
@@ -154,7 +177,7 @@ func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.ScheduleService
 	id, diags := convertStateIDToNummeric(diagElementHostTitle, d)
 	if diags.HasError() {
@@ -178,7 +201,7 @@ func setScheduleResourceData(d *schema.ResourceData, r *awx.Schedule) *schema.Re
 	d.Set("description", r.Description)
 	d.Set("enabled", r.Enabled)
 	d.Set("inventory", r.Inventory)
-	d.Set("extra_data", marshalYaml(r.ExtraData))
+	setVariables(d, "extra_data", marshalYaml(r.ExtraData))
 	d.SetId(strconv.Itoa(r.ID))
 	return d
 }
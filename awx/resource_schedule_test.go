@@ -0,0 +1,100 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceSchedule(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml"})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_schedule" "test" {
+  name                    = "test"
+  description             = %q
+  rrule                   = "DTSTART;TZID=UTC:20230101T000000 RRULE:FREQ=DAILY;INTERVAL=1"
+  unified_job_template_id = %d
+  extra_data              = "foo: bar"
+}
+`, description, jobTemplateID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "schedules", "awx_schedule"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "schedules", "awx_schedule.test"),
+					testCheckField(server, "schedules", "awx_schedule.test", "unified_job_template", jobTemplateID),
+					testCheckField(server, "schedules", "awx_schedule.test", "extra_data", map[string]interface{}{"foo": "bar"}),
+				),
+			},
+			// AWX gives extra_data back as an object, imported as YAML
+			{
+				ResourceName:            "awx_schedule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"extra_data"},
+			},
+			{
+				ResourceName:            "awx_schedule.test",
+				ImportState:             true,
+				ImportStateId:           "test++job++org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"extra_data"},
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "schedules", "awx_schedule.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "schedules", "name", "test", map[string]interface{}{"enabled": false}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "schedules", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceScheduleFieldError(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(server, fmt.Sprintf(`
+resource "awx_schedule" "test" {
+  name                    = "test"
+  rrule                   = "DTSTART;TZID=UTC:20230101T000000 RRULE:FREQ=DAILY;INTERVAL=1"
+  unified_job_template_id = %d
+  inventory               = 999
+}
+`, jobTemplateID)),
+				// the error is raised on the inventory attribute, quoted from the configuration
+				ExpectError: regexp.MustCompile(`(?s)\d+:\s+inventory\s+= 999.*inventory: Invalid pk`),
+			},
+		},
+	})
+}
@@ -45,9 +45,9 @@ package awx
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"time"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -86,7 +86,7 @@ func resourceSetting() *schema.Resource {
 type setting map[string]string
 
 func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.SettingService
 
 	_, err := awxService.GetSettingsBySlug("all", make(map[string]string))
@@ -97,31 +97,11 @@ func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		)
 	}
 
-	var map_decoded map[string]interface{}
-	var array_decoded []interface{}
-	var formatted_value interface{}
-
 	name := d.Get("name").(string)
 	value := d.Get("value").(string)
 
-	// Attempt to unmarshall string into a map
-	err = json.Unmarshal([]byte(value), &map_decoded)
-
-	if err != nil {
-		// Attempt to unmarshall string into an array
-		err = json.Unmarshal([]byte(value), &array_decoded)
-
-		if err != nil {
-			formatted_value = value
-		} else {
-			formatted_value = array_decoded
-		}
-	} else {
-		formatted_value = map_decoded
-	}
-
 	payload := map[string]interface{}{
-		name: formatted_value,
+		name: decodeSettingValue(value),
 	}
 
 	_, err = awxService.UpdateSettings("all", payload, make(map[string]string))
@@ -138,10 +118,10 @@ func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 func resourceSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.SettingService
 
-	_, err := awxService.GetSettingsBySlug("all", make(map[string]string))
+	settings, err := awxService.GetSettingsBySlug("all", make(map[string]string))
 	if err != nil {
 		return buildDiagnosticsMessage(
 			"Unable to fetch settings",
@@ -150,10 +130,51 @@ func resourceSettingRead(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	d.Set("name", d.Id())
-	d.Set("value", d.Get("value").(string))
+	// the configured value is kept while AWX holds the same one, as its
+	// formatting is lost once decoded
+	if raw, ok := (*settings)[d.Id()]; ok {
+		var current interface{}
+		if err := json.Unmarshal(raw, &current); err == nil && !settingValueEqual(decodeSettingValue(d.Get("value").(string)), current) {
+			d.Set("value", encodeSettingValue(current))
+		}
+	}
 	return diags
 }
 
+// decodeSettingValue returns the JSON object or array value holds, or value
+// itself when it holds neither.
+func decodeSettingValue(value string) interface{} {
+	var mapDecoded map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &mapDecoded); err == nil {
+		return mapDecoded
+	}
+	var arrayDecoded []interface{}
+	if err := json.Unmarshal([]byte(value), &arrayDecoded); err == nil {
+		return arrayDecoded
+	}
+	return value
+}
+
+// encodeSettingValue is the value attribute of a setting value read from AWX.
+func encodeSettingValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	encoded, _ := json.Marshal(v)
+	return string(encoded)
+}
+
+// settingValueEqual compares setting values, scalars sent as strings being
+// equal to the number or boolean AWX converts them to.
+func settingValueEqual(configured, current interface{}) bool {
+	var a, b interface{}
+	encoded, _ := json.Marshal(configured)
+	json.Unmarshal(encoded, &a)
+	encoded, _ = json.Marshal(current)
+	json.Unmarshal(encoded, &b)
+	return reflect.DeepEqual(a, b) || encodeSettingValue(a) == encodeSettingValue(b)
+}
+
 func resourceSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
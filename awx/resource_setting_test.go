@@ -0,0 +1,103 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceSetting(t *testing.T) {
+	server := newTestServer(t)
+	config := func(value string) string {
+		return testProviderConfig(server, `
+resource "awx_setting" "test" {
+  name  = "AD_HOC_COMMANDS"
+  value = `+value+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`jsonencode(["command"])`),
+				Check: func(*terraform.State) error {
+					if value := fmt.Sprint(server.Setting("jobs", "AD_HOC_COMMANDS")); value != "[command]" {
+						return fmt.Errorf("AD_HOC_COMMANDS is %s, expected [command]", value)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:      "awx_setting.test",
+				ImportState:       true,
+				ImportStateId:     "AD_HOC_COMMANDS",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(`<<EOF
+[
+  "command",
+  "shell"
+]
+EOF`),
+				Check: func(*terraform.State) error {
+					if value := fmt.Sprint(server.Setting("jobs", "AD_HOC_COMMANDS")); value != "[command shell]" {
+						return fmt.Errorf("AD_HOC_COMMANDS is %s, expected [command shell]", value)
+					}
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					server.SetSetting("jobs", "AD_HOC_COMMANDS", []interface{}{"shell"})
+				},
+				Config: config(`<<EOF
+[
+  "command",
+  "shell"
+]
+EOF`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceSettingScalar(t *testing.T) {
+	server := newTestServer(t)
+	config := testProviderConfig(server, `
+resource "awx_setting" "test" {
+  name  = "DEFAULT_JOB_TIMEOUT"
+  value = 15
+}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					server.SetSetting("jobs", "DEFAULT_JOB_TIMEOUT", 15)
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					server.SetSetting("jobs", "DEFAULT_JOB_TIMEOUT", 30)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
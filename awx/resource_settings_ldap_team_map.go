@@ -27,10 +27,10 @@ package awx
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"sync"
 	"time"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -95,7 +95,7 @@ func resourceSettingsLDAPTeamMapCreate(ctx context.Context, d *schema.ResourceDa
 	ldapTeamMapAccessMutex.Lock()
 	defer ldapTeamMapAccessMutex.Unlock()
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.SettingService
 
 	res, err := awxService.GetSettingsBySlug("ldap", make(map[string]string))
@@ -158,7 +158,7 @@ func resourceSettingsLDAPTeamMapUpdate(ctx context.Context, d *schema.ResourceDa
 	ldapTeamMapAccessMutex.Lock()
 	defer ldapTeamMapAccessMutex.Unlock()
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.SettingService
 
 	res, err := awxService.GetSettingsBySlug("ldap", make(map[string]string))
@@ -213,7 +213,7 @@ func resourceSettingsLDAPTeamMapUpdate(ctx context.Context, d *schema.ResourceDa
 
 func resourceSettingsLDAPTeamMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.SettingService
 
 	res, err := awxService.GetSettingsBySlug("ldap", make(map[string]string))
@@ -234,10 +234,10 @@ func resourceSettingsLDAPTeamMapRead(ctx context.Context, d *schema.ResourceData
 	}
 	mapdef, ok := tmaps[d.Id()]
 	if !ok {
-		return buildDiagnosticsMessage(
-			"Unable to fetch ldap team map",
-			"Unable to load ldap team map %v: not found", d.Id(),
-		)
+		// the team map was removed from AUTH_LDAP_TEAM_MAP outside of Terraform
+		log.Printf("[WARN] ldap team map %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
 	}
 
 	/*return buildDiagnosticsMessage(
@@ -272,7 +272,7 @@ func resourceSettingsLDAPTeamMapDelete(ctx context.Context, d *schema.ResourceDa
 	defer ldapTeamMapAccessMutex.Unlock()
 
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.SettingService
 
 	res, err := awxService.GetSettingsBySlug("ldap", make(map[string]string))
@@ -0,0 +1,85 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/denouche/terraform-provider-awx/awx/internal/fakeawx"
+)
+
+// testCheckLDAPTeamMap checks the entry of AUTH_LDAP_TEAM_MAP for team.
+func testCheckLDAPTeamMap(server *fakeawx.Server, team string, expected interface{}) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		teamMap, _ := server.Setting("ldap", "AUTH_LDAP_TEAM_MAP").(map[string]interface{})
+		if fmt.Sprint(teamMap[team]) != fmt.Sprint(expected) {
+			return fmt.Errorf("AUTH_LDAP_TEAM_MAP of %s is %v, expected %v", team, teamMap[team], expected)
+		}
+		return nil
+	}
+}
+
+func TestResourceSettingsLDAPTeamMap(t *testing.T) {
+	server := newTestServer(t)
+	config := func(organization string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_settings_ldap_team_map" "test" {
+  name         = "admins"
+  organization = %q
+  users        = ["CN=admins,OU=groups,DC=example,DC=com"]
+  remove       = true
+}
+`, organization))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckLDAPTeamMap(server, "admins", nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("org"),
+				Check: testCheckLDAPTeamMap(server, "admins", map[string]interface{}{
+					"organization": "org",
+					"users":        []interface{}{"CN=admins,OU=groups,DC=example,DC=com"},
+					"remove":       true,
+				}),
+			},
+			{
+				ResourceName:      "awx_settings_ldap_team_map.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("other"),
+				Check: testCheckLDAPTeamMap(server, "admins", map[string]interface{}{
+					"organization": "other",
+					"users":        []interface{}{"CN=admins,OU=groups,DC=example,DC=com"},
+					"remove":       true,
+				}),
+			},
+			{
+				PreConfig: func() {
+					server.SetSetting("ldap", "AUTH_LDAP_TEAM_MAP", map[string]interface{}{
+						"admins": map[string]interface{}{"organization": "other", "users": []interface{}{}, "remove": true},
+					})
+				},
+				Config:             config("other"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("other"),
+			},
+			{
+				PreConfig: func() {
+					server.SetSetting("ldap", "AUTH_LDAP_TEAM_MAP", map[string]interface{}{})
+				},
+				Config:             config("other"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,584 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &surveySpecResource{}
+	_ resource.ResourceWithImportState    = &surveySpecResource{}
+	_ resource.ResourceWithValidateConfig = &surveySpecResource{}
+)
+
+// surveyQuestionTypes are the types of the questions of a survey.
+var surveyQuestionTypes = []string{"text", "textarea", "password", "integer", "float", "multiplechoice", "multiselect"}
+
+// surveyVariableRegexp matches the names a survey can set as extra variables.
+var surveyVariableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// surveyEncrypted is what AWX answers in place of the defaults of the
+// password questions.
+const surveyEncrypted = "$encrypted$"
+
+// surveySpecResource manages the survey of a template of collection, job
+// templates or workflow job templates, whose ID is set to templateAttribute.
+type surveySpecResource struct {
+	typeSuffix        string
+	collection        string
+	templateAttribute string
+
+	client *awxClient
+}
+
+type surveySpecResourceModel struct {
+	ID          types.String          `tfsdk:"id"`
+	Name        types.String          `tfsdk:"name"`
+	Description types.String          `tfsdk:"description"`
+	Questions   []surveyQuestionModel `tfsdk:"question"`
+
+	// TemplateID is the job_template_id or workflow_job_template_id of the
+	// schema, it is read and written by getModel and setModel.
+	TemplateID types.Int64 `tfsdk:"-"`
+}
+
+type surveyQuestionModel struct {
+	Variable            types.String `tfsdk:"variable"`
+	QuestionName        types.String `tfsdk:"question_name"`
+	QuestionDescription types.String `tfsdk:"question_description"`
+	Type                types.String `tfsdk:"type"`
+	Required            types.Bool   `tfsdk:"required"`
+	Choices             types.List   `tfsdk:"choices"`
+	Default             types.String `tfsdk:"default"`
+	Min                 types.Int64  `tfsdk:"min"`
+	Max                 types.Int64  `tfsdk:"max"`
+}
+
+func (r *surveySpecResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeSuffix
+}
+
+func (r *surveySpecResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			r.templateAttribute: schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"question": schema.ListNestedBlock{
+				Description: "The questions of the survey, in the order they are asked.",
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"variable": schema.StringAttribute{
+							Required:    true,
+							Description: "The extra variable the answer is set to.",
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(surveyVariableRegexp, "must be a valid variable name"),
+							},
+						},
+						"question_name": schema.StringAttribute{
+							Required: true,
+						},
+						"question_description": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "One of: text, textarea, password, integer, float, multiplechoice, multiselect",
+							Validators: []validator.String{
+								stringvalidator.OneOf(surveyQuestionTypes...),
+							},
+						},
+						"required": schema.BoolAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+						},
+						"choices": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "The answers of the multiplechoice and multiselect questions.",
+						},
+						"default": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Sensitive:   true,
+							Default:     stringdefault.StaticString(""),
+							Description: "The default answer, the choices being separated by newlines for multiselect questions. Sensitive, as it holds the default of password questions. AWX never gives back the default of password questions, it is kept as configured.",
+						},
+						"min": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The minimum value of integer and float answers, or length of text answers.",
+						},
+						"max": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The maximum value of integer and float answers, or length of text answers.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *surveySpecResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = frameworkClient(req, resp)
+}
+
+// ValidateConfig checks the questions as AWX does, for a wrong survey to be
+// reported by the plan rather than half way through an apply.
+func (r *surveySpecResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var questions types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("question"), &questions)...)
+	if resp.Diagnostics.HasError() || questions.IsNull() || questions.IsUnknown() {
+		return
+	}
+
+	variables := make(map[string]int)
+	for i, element := range questions.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+		var question surveyQuestionModel
+		resp.Diagnostics.Append(object.As(ctx, &question, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		questionPath := path.Root("question").AtListIndex(i)
+		if !question.Variable.IsUnknown() {
+			variable := question.Variable.ValueString()
+			if j, ok := variables[variable]; ok {
+				resp.Diagnostics.AddAttributeError(
+					questionPath.AtName("variable"),
+					"Duplicate survey variable",
+					fmt.Sprintf("The variable %q is already set by question %d.", variable, j),
+				)
+			}
+			variables[variable] = i
+		}
+		resp.Diagnostics.Append(question.validate(ctx, questionPath)...)
+	}
+}
+
+// validate checks the choices, default, min and max of a question against its
+// type.
+func (q *surveyQuestionModel) validate(ctx context.Context, questionPath path.Path) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	if q.Type.IsUnknown() || q.Choices.IsUnknown() || q.Default.IsUnknown() || q.Min.IsUnknown() || q.Max.IsUnknown() {
+		return diags
+	}
+	questionType := q.Type.ValueString()
+
+	var choices []types.String
+	diags.Append(q.Choices.ElementsAs(ctx, &choices, false)...)
+	known := make(map[string]bool, len(choices))
+	for _, choice := range choices {
+		if choice.IsUnknown() {
+			return diags
+		}
+		known[choice.ValueString()] = true
+	}
+
+	hasChoices := questionType == "multiplechoice" || questionType == "multiselect"
+	if hasChoices && len(known) == 0 {
+		diags.AddAttributeError(questionPath.AtName("choices"), "Missing survey choices",
+			fmt.Sprintf("The choices of %s questions are required.", questionType))
+	}
+	if !hasChoices && !q.Choices.IsNull() {
+		diags.AddAttributeError(questionPath.AtName("choices"), "Invalid survey choices",
+			fmt.Sprintf("Only multiplechoice and multiselect questions have choices, not %s questions.", questionType))
+	}
+	if !q.Min.IsNull() && !q.Max.IsNull() && q.Min.ValueInt64() > q.Max.ValueInt64() {
+		diags.AddAttributeError(questionPath.AtName("max"), "Invalid survey bounds",
+			fmt.Sprintf("The max %d is lower than the min %d.", q.Max.ValueInt64(), q.Min.ValueInt64()))
+	}
+
+	defaultValue := q.Default.ValueString()
+	if defaultValue == "" {
+		return diags
+	}
+	defaultPath := questionPath.AtName("default")
+	switch questionType {
+	case "integer", "float":
+		var value float64
+		var err error
+		if questionType == "integer" {
+			var i int64
+			i, err = strconv.ParseInt(defaultValue, 10, 64)
+			value = float64(i)
+		} else {
+			value, err = strconv.ParseFloat(defaultValue, 64)
+		}
+		if err != nil {
+			diags.AddAttributeError(defaultPath, "Invalid survey default",
+				fmt.Sprintf("The default %q of an %s question is not an %s.", defaultValue, questionType, questionType))
+			return diags
+		}
+		if (!q.Min.IsNull() && value < float64(q.Min.ValueInt64())) || (!q.Max.IsNull() && value > float64(q.Max.ValueInt64())) {
+			diags.AddAttributeError(defaultPath, "Invalid survey default",
+				fmt.Sprintf("The default %s is not between the min and max of the question.", defaultValue))
+		}
+	case "text", "textarea", "password":
+		length := int64(len(defaultValue))
+		if (!q.Min.IsNull() && length < q.Min.ValueInt64()) || (!q.Max.IsNull() && length > q.Max.ValueInt64()) {
+			diags.AddAttributeError(defaultPath, "Invalid survey default",
+				"The length of the default is not between the min and max of the question.")
+		}
+	case "multiplechoice", "multiselect":
+		answers := []string{defaultValue}
+		if questionType == "multiselect" {
+			answers = strings.Split(defaultValue, "\n")
+		}
+		for _, answer := range answers {
+			if !known[answer] {
+				diags.AddAttributeError(defaultPath, "Invalid survey default",
+					fmt.Sprintf("The default %q is not one of the choices of the question.", answer))
+			}
+		}
+	}
+	return diags
+}
+
+// ImportState imports the survey of a template by the ID or the named URL of
+// the template.
+func (r *surveySpecResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveIDOrNamedURL(r.client, r.collection, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import SurveySpec", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(id))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(r.templateAttribute), id)...)
+}
+
+func (r *surveySpecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data surveySpecResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.Plan, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.save(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.read(ctx, &data, &resp.State)...)
+}
+
+func (r *surveySpecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data surveySpecResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data, &resp.State)...)
+}
+
+func (r *surveySpecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data surveySpecResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.Plan, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.save(&data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.read(ctx, &data, &resp.State)...)
+}
+
+func (r *surveySpecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data surveySpecResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.TemplateID.ValueInt64()
+	if err := r.client.delete(r.endpoint(id)); err != nil && !isNotFoundError(err) {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagDeleteFail(
+			"SurveySpec",
+			fmt.Sprintf("%s %d, got %s ", r.templateName(), id, err.Error()),
+		))
+	}
+}
+
+// save sends the survey of data, which replaces the one of the template.
+func (r *surveySpecResource) save(data *surveySpecResourceModel, diags *fwdiag.Diagnostics) {
+	id := data.TemplateID.ValueInt64()
+	if err := r.client.postJSON(r.endpoint(id), data.apiParams(), nil); err != nil {
+		appendSDKDiagnostics(diags, buildDiagAPIFail(
+			"Unable to save SurveySpec",
+			err,
+			"SurveySpec of %s %d failed to save", r.templateName(), id,
+		))
+	}
+}
+
+// read refreshes data from the survey of its template and writes it to state.
+// A survey removed, or whose template is deleted, outside of Terraform is
+// removed from the state.
+func (r *surveySpecResource) read(ctx context.Context, data *surveySpecResourceModel, state *tfsdk.State) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	id := data.TemplateID.ValueInt64()
+	res := new(surveySpec)
+	err := r.client.getJSON(r.endpoint(id), res, map[string]string{})
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] %s %d not found, removing its survey from the state", r.templateName(), id)
+			state.RemoveResource(ctx)
+			return diags
+		}
+		appendSDKDiagnostics(&diags, buildDiagNotFoundFail(r.templateName()+" survey", int(id), err))
+		return diags
+	}
+	if len(res.Spec) == 0 {
+		log.Printf("[WARN] %s %d has no survey, removing it from the state", r.templateName(), id)
+		state.RemoveResource(ctx)
+		return diags
+	}
+
+	data.setFromAPI(id, res)
+	return r.setModel(ctx, state, data)
+}
+
+func (r *surveySpecResource) endpoint(id int64) string {
+	return fmt.Sprintf("/api/v2/%s/%d/survey_spec/", r.collection, id)
+}
+
+// templateName is the name of the templates of the resource in messages, as
+// "job template".
+func (r *surveySpecResource) templateName() string {
+	return strings.ReplaceAll(strings.TrimSuffix(r.collection, "s"), "_", " ")
+}
+
+// getModel reads a plan or a state into data, the template attribute going
+// to data.TemplateID.
+func (r *surveySpecResource) getModel(ctx context.Context, src modelGetter, data *surveySpecResourceModel) fwdiag.Diagnostics {
+	templateID, diags := getModelWithout(ctx, src, r.templateAttribute, data)
+	data.TemplateID = templateID
+	return diags
+}
+
+// setModel writes data to state, the reverse of getModel.
+func (r *surveySpecResource) setModel(ctx context.Context, state *tfsdk.State, data *surveySpecResourceModel) fwdiag.Diagnostics {
+	return setModelWith(ctx, state, data, r.templateAttribute, data.TemplateID)
+}
+
+func (data *surveySpecResourceModel) apiParams() map[string]interface{} {
+	spec := make([]interface{}, 0, len(data.Questions))
+	for _, question := range data.Questions {
+		spec = append(spec, question.apiParams())
+	}
+	return map[string]interface{}{
+		"name":        data.Name.ValueString(),
+		"description": data.Description.ValueString(),
+		"spec":        spec,
+	}
+}
+
+func (q *surveyQuestionModel) apiParams() map[string]interface{} {
+	question := map[string]interface{}{
+		"variable":             q.Variable.ValueString(),
+		"question_name":        q.QuestionName.ValueString(),
+		"question_description": q.QuestionDescription.ValueString(),
+		"type":                 q.Type.ValueString(),
+		"required":             q.Required.ValueBool(),
+	}
+	if !q.Choices.IsNull() {
+		choices := make([]string, 0, len(q.Choices.Elements()))
+		for _, choice := range q.Choices.Elements() {
+			choices = append(choices, choice.(types.String).ValueString())
+		}
+		question["choices"] = choices
+	}
+	// the defaults of the numeric questions are numbers, the validation of
+	// the config leaves the others as they are for AWX to report
+	if defaultValue := q.Default.ValueString(); defaultValue != "" {
+		question["default"] = defaultValue
+		switch q.Type.ValueString() {
+		case "integer":
+			if i, err := strconv.ParseInt(defaultValue, 10, 64); err == nil {
+				question["default"] = i
+			}
+		case "float":
+			if f, err := strconv.ParseFloat(defaultValue, 64); err == nil {
+				question["default"] = f
+			}
+		}
+	}
+	if !q.Min.IsNull() {
+		question["min"] = q.Min.ValueInt64()
+	}
+	if !q.Max.IsNull() {
+		question["max"] = q.Max.ValueInt64()
+	}
+	return question
+}
+
+// surveySpec is a survey as AWX returns it. Depending on the AWX release and
+// on the client that saved it, choices are a list or a text of one choice per
+// line, and default, min and max numbers or strings.
+type surveySpec struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Spec        []surveyQuestion `json:"spec"`
+}
+
+type surveyQuestion struct {
+	Variable            string      `json:"variable"`
+	QuestionName        string      `json:"question_name"`
+	QuestionDescription string      `json:"question_description"`
+	Type                string      `json:"type"`
+	Required            bool        `json:"required"`
+	Choices             interface{} `json:"choices"`
+	Default             interface{} `json:"default"`
+	Min                 interface{} `json:"min"`
+	Max                 interface{} `json:"max"`
+}
+
+func (data *surveySpecResourceModel) setFromAPI(id int64, r *surveySpec) {
+	prior := make(map[string]surveyQuestionModel, len(data.Questions))
+	for _, question := range data.Questions {
+		prior[question.Variable.ValueString()] = question
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+	data.TemplateID = types.Int64Value(id)
+	data.Name = types.StringValue(r.Name)
+	data.Description = types.StringValue(r.Description)
+	data.Questions = make([]surveyQuestionModel, 0, len(r.Spec))
+	for _, question := range r.Spec {
+		data.Questions = append(data.Questions, surveyQuestionModel{
+			Variable:            types.StringValue(question.Variable),
+			QuestionName:        types.StringValue(question.QuestionName),
+			QuestionDescription: types.StringValue(question.QuestionDescription),
+			Type:                types.StringValue(question.Type),
+			Required:            types.BoolValue(question.Required),
+			Choices:             surveyChoices(question.Choices),
+			Default:             surveyDefault(question, prior[question.Variable].Default),
+			Min:                 surveyBound(question.Min),
+			Max:                 surveyBound(question.Max),
+		})
+	}
+}
+
+// surveyChoices returns the choices of a question, null when it has none.
+func surveyChoices(v interface{}) types.List {
+	var choices []string
+	switch v := v.(type) {
+	case string:
+		for _, choice := range strings.Split(v, "\n") {
+			if choice != "" {
+				choices = append(choices, choice)
+			}
+		}
+	case []interface{}:
+		for _, choice := range v {
+			choices = append(choices, fmt.Sprint(choice))
+		}
+	}
+	if len(choices) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	elements := make([]attr.Value, 0, len(choices))
+	for _, choice := range choices {
+		elements = append(elements, types.StringValue(choice))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// surveyDefault returns the default of a question. The prior default is kept
+// for password questions, whose default AWX answers as `$encrypted$`, and for
+// numbers written differently, as `1.50` given back as 1.5.
+func surveyDefault(question surveyQuestion, prior types.String) types.String {
+	var value string
+	switch v := question.Default.(type) {
+	case nil:
+	case string:
+		value = v
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		value = fmt.Sprint(v)
+	}
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.StringValue(value)
+	}
+	if question.Type == "password" && value == surveyEncrypted {
+		return prior
+	}
+	if question.Type == "integer" || question.Type == "float" {
+		a, errA := strconv.ParseFloat(value, 64)
+		b, errB := strconv.ParseFloat(prior.ValueString(), 64)
+		if errA == nil && errB == nil && a == b {
+			return prior
+		}
+	}
+	return types.StringValue(value)
+}
+
+// surveyBound returns the min or max of a question, null when it has none.
+func surveyBound(v interface{}) types.Int64 {
+	switch v := v.(type) {
+	case float64:
+		return types.Int64Value(int64(v))
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return types.Int64Value(i)
+		}
+	}
+	return types.Int64Null()
+}
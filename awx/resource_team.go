@@ -92,7 +92,7 @@ func resourceTeam() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("teams"),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -104,7 +104,23 @@ func resourceTeam() *schema.Resource {
 }
 
 func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "teams", map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		if err := removeUndeclaredTeamRoles(m, id, d.Get("role_entitlement").(*schema.Set)); err != nil {
+			return buildDiagnosticsMessage(
+				"Create: team role entitlement not removed",
+				"Failed to remove the role entitlements of the adopted team %v: %s", id, err.Error(),
+			)
+		}
+		return resourceTeamUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.TeamService
 
 	orgID := d.Get("organization_id").(int)
@@ -118,7 +134,7 @@ func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface
 		return buildDiagnosticsMessage("Create: Fail to find Team", "Fail to find Team %s Organization ID %v, %s", teamName, orgID, err.Error())
 	}
 	if len(res.Results) >= 1 {
-		return buildDiagnosticsMessage("Create: Already exist", "Team with name %s  already exists in the Organization ID %v", teamName, orgID)
+		return buildDiagnosticsMessage("Create: Already exist", "Team with name %s  already exists in the Organization ID %v, set adopt_existing in the provider to take it over", teamName, orgID)
 	}
 
 	result, err := awxService.CreateTeam(map[string]interface{}{
@@ -127,7 +143,7 @@ func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface
 		"organization": d.Get("organization_id").(int),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Create: Team not created", "Team with name %s  in the Organization ID %v not created, %s", teamName, orgID, err.Error())
+		return buildDiagAPIFail("Create: Team not created", err, "Team with name %s  in the Organization ID %v not created", teamName, orgID)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -147,7 +163,7 @@ func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func roleTeamEntitlementUpdate(m interface{}, team_id int, roles []interface{}, remove bool) error {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.TeamService
 
 	for _, v := range roles {
@@ -167,8 +183,30 @@ func roleTeamEntitlementUpdate(m interface{}, team_id int, roles []interface{},
 	return nil
 }
 
+// removeUndeclaredTeamRoles removes from an adopted team the role
+// entitlements the configuration does not declare, Terraform owning them from
+// then on.
+func removeUndeclaredTeamRoles(m interface{}, teamID int, declared *schema.Set) error {
+	client := m.(*awxClient)
+	entitlements, _, err := client.TeamService.ListTeamRoleEntitlements(teamID, make(map[string]string))
+	if err != nil {
+		return err
+	}
+	keep := make(map[int]bool)
+	for _, v := range declared.List() {
+		keep[v.(map[string]interface{})["role_id"].(int)] = true
+	}
+	var remove []interface{}
+	for _, e := range entitlements {
+		if !keep[e.ID] {
+			remove = append(remove, map[string]interface{}{"role_id": e.ID})
+		}
+	}
+	return roleTeamEntitlementUpdate(m, teamID, remove, true)
+}
+
 func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.TeamService
 
 	id, diags := convertStateIDToNummeric("Update Team", d)
@@ -211,7 +249,7 @@ func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		"organization": d.Get("organization_id").(int),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Update: Failed To Update Team", "Fail to get Team with ID %v, got %s", id, err.Error())
+		return buildDiagAPIFail("Update: Failed To Update Team", err, "Fail to update Team with ID %v", id)
 	}
 	d.Partial(false)
 	return resourceTeamRead(ctx, d, m)
@@ -219,7 +257,7 @@ func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.TeamService
 
 	id, diags := convertStateIDToNummeric("Read Team", d)
@@ -229,6 +267,9 @@ func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	team, err := awxService.GetTeamByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, "team", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("team", id, err)
 	}
 	entitlements, _, err := awxService.ListTeamRoleEntitlements(id, make(map[string]string))
@@ -243,7 +284,7 @@ func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}
 func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	digMessagePart := "Team"
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.TeamService
 
 	id, diags := convertStateIDToNummeric("Delete Team", d)
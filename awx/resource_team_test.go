@@ -0,0 +1,119 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceTeam(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	readRole := server.Role("organizations", orgID, "read_role")
+	memberRole := server.Role("organizations", orgID, "member_role")
+	config := func(description string, roleID int) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_team" "test" {
+  name            = "test"
+  description     = %q
+  organization_id = %d
+
+  role_entitlement {
+    role_id = %d
+  }
+}
+`, description, orgID, roleID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "teams", "awx_team"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created", readRole),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "teams", "awx_team.test"),
+					testCheckField(server, "teams", "awx_team.test", "organization", orgID),
+					testCheckAssociated(server, "teams", "awx_team.test", "roles", readRole),
+				),
+			},
+			{
+				ResourceName:      "awx_team.test",
+				ImportState:       true,
+				ImportStateId:     "test++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated", memberRole),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckField(server, "teams", "awx_team.test", "description", "updated"),
+					testCheckAssociated(server, "teams", "awx_team.test", "roles", memberRole),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.Associate("teams", testObjectID(t, server, "teams", "name", "test"), "roles", readRole)
+				},
+				Config:             config("updated", memberRole),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated", memberRole),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "teams", "name", "test", map[string]interface{}{"description": "changed"}),
+				Config:             config("updated", memberRole),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceTeamAdoptExisting(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	readRole := server.Role("organizations", orgID, "read_role")
+	memberRole := server.Role("organizations", orgID, "member_role")
+	id := server.Add("teams", map[string]interface{}{"name": "test", "organization": orgID})
+	server.Associate("teams", id, "roles", readRole)
+	config := fmt.Sprintf(`
+provider "awx" {
+  hostname       = %q
+  token          = "test"
+  max_retries    = 0
+  adopt_existing = true
+}
+
+resource "awx_team" "test" {
+  name            = "test"
+  organization_id = %d
+
+  role_entitlement {
+    role_id = %d
+  }
+}
+`, server.URL, orgID, memberRole)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "teams", "awx_team"),
+		Steps: []resource.TestStep{
+			// the roles held before the adoption are not left as drift
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_team.test", "id", strconv.Itoa(id)),
+					testCheckAssociated(server, "teams", "awx_team.test", "roles", memberRole),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
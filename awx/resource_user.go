@@ -30,7 +30,6 @@ import (
 	"fmt"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -87,15 +86,24 @@ func resourceUser() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importStateNamedURL("users"),
 		},
 	}
 }
 
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "users", map[string]interface{}{
+		"username": d.Get("username").(string),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceUserUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
 
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.UserService
 	userName := d.Get("username").(string)
 
@@ -109,12 +117,11 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 		"is_system_auditor": d.Get("is_system_auditor").(bool),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new user",
-			Detail:   fmt.Sprintf("Unable to create new user: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create new user",
+			err,
+			"Unable to create new user",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -134,7 +141,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func roleUserEntitlementUpdate(m interface{}, user_id int, roles []interface{}, remove bool) error {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.UserService
 
 	for _, v := range roles {
@@ -155,7 +162,7 @@ func roleUserEntitlementUpdate(m interface{}, user_id int, roles []interface{},
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.UserService
 	var diags diag.Diagnostics
 	if diags.HasError() {
@@ -201,12 +208,11 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		"is_system_auditor": d.Get("is_system_auditor").(bool),
 	}, nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update user",
-			Detail:   fmt.Sprintf("Unable to update new user: %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to update user",
+			err,
+			"Unable to update new user",
+		)...)
 	}
 
 	return resourceUserRead(ctx, d, m)
@@ -214,12 +220,15 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	var diags diag.Diagnostics
 	awxService := client.UserService
 	id, _ := strconv.Atoi(d.Id())
 	res, err := awxService.GetUserByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, "User", err) {
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch user",
@@ -232,8 +241,8 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		return buildDiagNotFoundFail("user roles", id, err)
 	}
 
+	// AWX never returns the password, the configured one is kept
 	d.Set("username", res.Username)
-	d.Set("password", res.Password)
 	d.Set("first_name", res.FirstName)
 	d.Set("last_name", res.LastName)
 	d.Set("email", res.Email)
@@ -259,7 +268,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.UserService
 	id, diags := convertStateIDToNummeric("Delete User", d)
 
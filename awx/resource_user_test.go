@@ -0,0 +1,71 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceUser(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	memberRole := server.Role("organizations", orgID, "member_role")
+	config := func(email string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_user" "test" {
+  username   = "test"
+  password   = "changeme"
+  first_name = "Test"
+  last_name  = "User"
+  email      = %q
+
+  role_entitlement {
+    role_id = %d
+  }
+}
+`, email, memberRole))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "users", "awx_user"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("test@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "users", "awx_user.test"),
+					testCheckField(server, "users", "awx_user.test", "first_name", "Test"),
+					testCheckAssociated(server, "users", "awx_user.test", "roles", memberRole),
+				),
+			},
+			{
+				ResourceName:            "awx_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				Config: config("updated@example.com"),
+				Check:  testCheckField(server, "users", "awx_user.test", "email", "updated@example.com"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "users", "username", "test", map[string]interface{}{"is_system_auditor": true}),
+				Config:             config("updated@example.com"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated@example.com"),
+			},
+			{
+				PreConfig: func() {
+					server.Disassociate("users", testObjectID(t, server, "users", "username", "test"), "roles", memberRole)
+				},
+				Config:             config("updated@example.com"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
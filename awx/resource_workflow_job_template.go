@@ -45,11 +45,20 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Description: "Optional description of this workflow job template.",
 			},
 			"variables": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "",
-				StateFunc:   normalizeJsonYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				Description:      "",
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"variables"},
+				Description:   "Variables as a map, the values holding JSON as numbers or the result of jsonencode being decoded. Conflicts with variables.",
 			},
 			"organization_id": {
 				Type:        schema.TypeInt,
@@ -73,10 +82,10 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Default:  false,
 			},
 			"inventory_id": {
-				Type:        schema.TypeString,
+				Type:        schema.TypeInt,
 				Optional:    true,
 				Description: "Inventory applied as a prompt, assuming job template prompts for inventory. (id, default=``)",
-				Default:     "",
+				Default:     0,
 			},
 			"limit": {
 				Type:     schema.TypeString,
@@ -109,25 +118,42 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Default:  "",
 			},
 			"webhook_credential": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Optional: true,
-				Default:  "",
 			},
 		},
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			numericIDStateUpgrader(0, "inventory_id"),
+			numericIDStateUpgrader(1, "webhook_credential"),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateNamedURL("workflow_job_templates"),
+		},
 	}
 }
 
 func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if id, diags := findExistingObject(m, "workflow_job_templates", map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceWorkflowJobTemplateUpdate(ctx, d, m)
+	}
+
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.WorkflowJobTemplateService
 
 	result, err := awxService.CreateWorkflowJobTemplate(map[string]interface{}{
 		"name":                     d.Get("name").(string),
 		"description":              d.Get("description").(string),
 		"organization":             d.Get("organization_id").(int),
-		"inventory":                AtoipOr(d.Get("inventory_id").(string), nil),
-		"extra_vars":               d.Get("variables").(string),
+		"inventory":                IntpOrNil(d.Get("inventory_id").(int)),
+		"extra_vars":               getVariablesText(d, "variables", "variables_map"),
 		"survey_enabled":           d.Get("survey_enabled").(bool),
 		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
 		"ask_variables_on_launch":  d.Get("ask_variables_on_launch").(bool),
@@ -137,16 +163,15 @@ func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceDa
 		"ask_scm_branch_on_launch": d.Get("ask_scm_branch_on_launch").(bool),
 		"ask_limit_on_launch":      d.Get("ask_limit_on_launch").(bool),
 		"webhook_service":          d.Get("webhook_service").(string),
-		"webhook_credential":       d.Get("webhook_credential").(string),
+		"webhook_credential":       IntpOrNil(d.Get("webhook_credential").(int)),
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create WorkflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplate with name %s failed to create %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplate",
+			err,
+			"WorkflowJobTemplate with name %s failed to create", d.Get("name").(string),
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -155,7 +180,7 @@ func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceDa
 
 func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.WorkflowJobTemplateService
 	id, diags := convertStateIDToNummeric("Update WorkflowJobTemplate", d)
 	if diags.HasError() {
@@ -172,8 +197,8 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 		"name":                     d.Get("name").(string),
 		"description":              d.Get("description").(string),
 		"organization":             d.Get("organization_id").(int),
-		"inventory":                AtoipOr(d.Get("inventory_id").(string), nil),
-		"extra_vars":               d.Get("variables").(string),
+		"inventory":                IntpOrNil(d.Get("inventory_id").(int)),
+		"extra_vars":               getVariablesText(d, "variables", "variables_map"),
 		"survey_enabled":           d.Get("survey_enabled").(bool),
 		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
 		"ask_variables_on_launch":  d.Get("ask_variables_on_launch").(bool),
@@ -183,15 +208,14 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 		"ask_scm_branch_on_launch": d.Get("ask_scm_branch_on_launch").(bool),
 		"ask_limit_on_launch":      d.Get("ask_limit_on_launch").(bool),
 		"webhook_service":          d.Get("webhook_service").(string),
-		"webhook_credential":       d.Get("webhook_credential").(string),
+		"webhook_credential":       IntpOrNil(d.Get("webhook_credential").(int)),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update WorkflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplate with name %s in the project id %d failed to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to update WorkflowJobTemplate",
+			err,
+			"WorkflowJobTemplate with name %s failed to update", d.Get("name").(string),
+		)...)
 	}
 
 	return resourceWorkflowJobTemplateRead(ctx, d, m)
@@ -199,7 +223,7 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 
 func resourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.WorkflowJobTemplateService
 	id, diags := convertStateIDToNummeric("Read WorkflowJobTemplate", d)
 	if diags.HasError() {
@@ -208,6 +232,9 @@ func resourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceData
 
 	res, err := awxService.GetWorkflowJobTemplateByID(id, make(map[string]string))
 	if err != nil {
+		if removeFromStateIfNotFound(d, "workflow job template", err) {
+			return nil
+		}
 		return buildDiagNotFoundFail("workflow job template", id, err)
 
 	}
@@ -216,7 +243,7 @@ func resourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceWorkflowJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*awx.AWX)
+	client := m.(*awxClient)
 	awxService := client.WorkflowJobTemplateService
 	id, diags := convertStateIDToNummeric(diagElementHostTitle, d)
 	if diags.HasError() {
@@ -237,8 +264,8 @@ func setWorkflowJobTemplateResourceData(d *schema.ResourceData, r *awx.WorkflowJ
 
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
-	d.Set("organization_id", strconv.Itoa(r.Organization))
-	d.Set("inventory_id", strconv.Itoa(r.Inventory))
+	d.Set("organization_id", r.Organization)
+	d.Set("inventory_id", r.Inventory)
 	d.Set("survey_enabled", r.SurveyEnabled)
 	d.Set("allow_simultaneous", r.AllowSimultaneous)
 	d.Set("ask_variables_on_launch", r.AskVariablesOnLaunch)
@@ -248,8 +275,9 @@ func setWorkflowJobTemplateResourceData(d *schema.ResourceData, r *awx.WorkflowJ
 	d.Set("ask_scm_branch_on_launch", r.AskScmBranchOnLaunch)
 	d.Set("ask_limit_on_launch", r.AskLimitOnLaunch)
 	d.Set("webhook_service", r.WebhookService)
-	d.Set("webhook_credential", r.WebhookCredential)
-	d.Set("variables", normalizeJsonYaml(r.ExtraVars))
+	webhookCredential, _ := r.WebhookCredential.(float64)
+	d.Set("webhook_credential", int(webhookCredential))
+	setVariablesOrMap(d, "variables", "variables_map", r.ExtraVars)
 
 	d.SetId(strconv.Itoa(r.ID))
 	return d
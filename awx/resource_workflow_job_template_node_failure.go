@@ -15,29 +15,27 @@ resource "awx_workflow_job_template_node_failure" "k3s" {
 }
 ```
 
+Import
+
+The ID is `<workflow_job_template_node_id>:<id>`, the ID of the node this one follows and its own.
+
+```shell
+terraform import awx_workflow_job_template_node_failure.k3s 21:22
+```
+
 */
 package awx
 
 import (
-    "context"
-
-    awx "github.com/denouche/goawx/client"
-    "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-    "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
-func resourceWorkflowJobTemplateNodeFailure() *schema.Resource {
-    return &schema.Resource{
-        CreateContext: resourceWorkflowJobTemplateNodeFailureCreate,
-        ReadContext:   resourceWorkflowJobTemplateNodeRead,
-        UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
-        DeleteContext: resourceWorkflowJobTemplateNodeDelete,
-        Schema:        workflowJobNodeSchema,
-    }
-}
-
-func resourceWorkflowJobTemplateNodeFailureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-    client := m.(*awx.AWX)
-    awxService := client.WorkflowJobTemplateNodeFailureService
-    return createNodeForWorkflowJob(awxService, ctx, d, m)
+func newWorkflowJobTemplateNodeFailureResource() resource.Resource {
+	return &workflowJobTemplateNodeResource{
+		typeSuffix: "_workflow_job_template_node_failure",
+		stepService: func(client *awxClient) *awx.WorkflowJobTemplateNodeStepService {
+			return client.WorkflowJobTemplateNodeFailureService
+		},
+	}
 }
@@ -1,116 +1,448 @@
 package awx
 
 import (
-    "context"
-    "fmt"
-    "log"
-    "strconv"
-
-    awx "github.com/denouche/goawx/client"
-    "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-    "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &workflowJobTemplateNodeResource{}
+	_ resource.ResourceWithImportState = &workflowJobTemplateNodeResource{}
 )
 
-var workflowJobNodeSchema = map[string]*schema.Schema{
-
-    "extra_data": {
-        Type:        schema.TypeString,
-        Optional:    true,
-        Default:     "",
-        Description: "",
-        StateFunc:   normalizeJsonYaml,
-    },
-    "workflow_job_template_node_id": {
-        Type:        schema.TypeInt,
-        Required:    true,
-        Description: "The workflow_job_template_node id from with the new node will start",
-    },
-    "inventory_id": {
-        Type:        schema.TypeInt,
-        Optional:    true,
-        Description: "Inventory applied as a prompt, assuming job template prompts for inventory.",
-    },
-    "scm_branch": {
-        Type:     schema.TypeString,
-        Optional: true,
-        Default:  "",
-    },
-    "job_type": {
-        Type:     schema.TypeString,
-        Optional: true,
-        Default:  "run",
-    },
-    "job_tags": {
-        Type:     schema.TypeString,
-        Optional: true,
-    },
-    "skip_tags": {
-        Type:     schema.TypeString,
-        Optional: true,
-    },
-    "limit": {
-        Type:     schema.TypeString,
-        Optional: true,
-    },
-    "diff_mode": {
-        Type:     schema.TypeBool,
-        Optional: true,
-    },
-    "verbosity": {
-        Type:     schema.TypeInt,
-        Optional: true,
-        Default:  0,
-    },
-    "workflow_job_template_id": {
-        Type:     schema.TypeInt,
-        Required: true,
-    },
-    "unified_job_template_id": {
-        Type:     schema.TypeInt,
-        Required: true,
-    },
-    "all_parents_must_converge": {
-        Type:     schema.TypeBool,
-        Optional: true,
-        Default:  true,
-    },
-    "identifier": {
-        Type:     schema.TypeString,
-        Required: true,
-    },
-}
-
-func createNodeForWorkflowJob(awxService *awx.WorkflowJobTemplateNodeStepService, ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-    var diags diag.Diagnostics
-    templateNodeID := d.Get("workflow_job_template_node_id").(int)
-    result, err := awxService.CreateWorkflowJobTemplateNodeStep(templateNodeID, map[string]interface{}{
-        "extra_data":            d.Get("extra_data").(string),
-        "inventory":             d.Get("inventory_id").(int),
-        "scm_branch":            d.Get("scm_branch").(string),
-        "skip_tags":             d.Get("skip_tags").(string),
-        "job_type":              d.Get("job_type").(string),
-        "job_tags":              d.Get("job_tags").(string),
-        "limit":                 d.Get("limit").(string),
-        "diff_mode":             d.Get("diff_mode").(bool),
-        "verbosity":             d.Get("verbosity").(int),
-        "workflow_job_template": d.Get("workflow_job_template_id").(int),
-        "unified_job_template":  d.Get("unified_job_template_id").(int),
-        //"failure_nodes":         d.Get("failure_nodes").([]interface{}),
-        //"success_nodes":         d.Get("success_nodes").([]interface{}),
-        //"always_nodes":          d.Get("always_nodes").([]interface{}),
-
-        "all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
-        "identifier":                d.Get("identifier").(string),
-    }, map[string]string{})
-    if err != nil {
-        log.Printf("Fail to Create Template %v", err)
-        diags = append(diags, diag.Diagnostic{
-            Severity: diag.Error,
-            Summary:  "Unable to create WorkflowJobTemplateNodeSuccess",
-            Detail:   fmt.Sprintf("WorkflowJobTemplateNodeSuccess with JobTemplateID %d failed to create %s", d.Get("unified_job_template_id").(int), err.Error()),
-        })
-        return diags
-    }
-    d.SetId(strconv.Itoa(result.ID))
-    return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
+// workflowJobTemplateNodeResource manages awx_workflow_job_template_node, and
+// the nodes started on the success, failure or always of another one when
+// stepService is set.
+type workflowJobTemplateNodeResource struct {
+	typeSuffix  string
+	stepService func(client *awxClient) *awx.WorkflowJobTemplateNodeStepService
+
+	client *awxClient
+}
+
+type workflowJobTemplateNodeResourceModel struct {
+	ID                     types.String   `tfsdk:"id"`
+	ExtraData              jsonYAMLString `tfsdk:"extra_data"`
+	ExtraDataMap           types.Map      `tfsdk:"extra_data_map"`
+	InventoryID            types.Int64    `tfsdk:"inventory_id"`
+	ScmBranch              types.String   `tfsdk:"scm_branch"`
+	JobType                types.String   `tfsdk:"job_type"`
+	JobTags                types.String   `tfsdk:"job_tags"`
+	SkipTags               types.String   `tfsdk:"skip_tags"`
+	Limit                  types.String   `tfsdk:"limit"`
+	DiffMode               types.Bool     `tfsdk:"diff_mode"`
+	Verbosity              types.Int64    `tfsdk:"verbosity"`
+	WorkflowJobTemplateID  types.Int64    `tfsdk:"workflow_job_template_id"`
+	UnifiedJobTemplateID   types.Int64    `tfsdk:"unified_job_template_id"`
+	AllParentsMustConverge types.Bool     `tfsdk:"all_parents_must_converge"`
+	Identifier             types.String   `tfsdk:"identifier"`
+
+	// WorkflowJobTemplateNodeID is only in the schema of the steps, it is
+	// read and written by getModel and setModel.
+	WorkflowJobTemplateNodeID types.Int64 `tfsdk:"-"`
+}
+
+func (r *workflowJobTemplateNodeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeSuffix
+}
+
+func (r *workflowJobTemplateNodeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"extra_data": schema.StringAttribute{
+			CustomType: jsonYAMLStringType{},
+			Optional:   true,
+			Computed:   true,
+			Default:    stringdefault.StaticString(""),
+			Validators: []validator.String{
+				jsonYAMLObjectValidator{},
+			},
+		},
+		"extra_data_map": schema.MapAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Extra data as a map, the values holding JSON as numbers or the result of jsonencode being decoded. Conflicts with extra_data.",
+			Validators: []validator.Map{
+				mapvalidator.ConflictsWith(path.MatchRoot("extra_data")),
+			},
+		},
+		"inventory_id": schema.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     int64default.StaticInt64(0),
+			Description: "Inventory applied as a prompt, assuming job template prompts for inventory.",
+		},
+		"scm_branch": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(""),
+		},
+		"job_type": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString("run"),
+			Validators: []validator.String{
+				stringvalidator.OneOf(jobTypes...),
+			},
+		},
+		"job_tags": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(""),
+		},
+		"skip_tags": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(""),
+		},
+		"limit": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(""),
+		},
+		"diff_mode": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+		},
+		"verbosity": schema.Int64Attribute{
+			Optional: true,
+			Computed: true,
+			Default:  int64default.StaticInt64(0),
+			Validators: []validator.Int64{
+				int64validator.Between(0, 5),
+			},
+		},
+		"workflow_job_template_id": schema.Int64Attribute{
+			Required: true,
+		},
+		"unified_job_template_id": schema.Int64Attribute{
+			Required: true,
+		},
+		"all_parents_must_converge": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(true),
+		},
+		"identifier": schema.StringAttribute{
+			Required: true,
+		},
+	}
+	if r.stepService != nil {
+		attributes["workflow_job_template_node_id"] = schema.Int64Attribute{
+			Required:    true,
+			Description: "The workflow_job_template_node id from with the new node will start",
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (r *workflowJobTemplateNodeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = frameworkClient(req, resp)
+}
+
+// ImportState imports a node by ID or named URL, and a step from an ID of the
+// form `<workflow_job_template_node_id>:<id>` whose parts are numeric IDs or
+// named URLs.
+func (r *workflowJobTemplateNodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if r.stepService != nil {
+		parts, err := splitCompositeID(req.ID, "workflow_job_template_node_id", "id")
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to import WorkflowJobTemplateNode", err.Error())
+			return
+		}
+		parentID, err := resolveIDOrNamedURL(r.client, "workflow_job_template_nodes", parts[0])
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to import WorkflowJobTemplateNode", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workflow_job_template_node_id"), parentID)...)
+		id = parts[1]
+	}
+
+	nodeID, err := resolveIDOrNamedURL(r.client, "workflow_job_template_nodes", id)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import WorkflowJobTemplateNode", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(nodeID))...)
+}
+
+func (r *workflowJobTemplateNodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data workflowJobTemplateNodeResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.Plan, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result *awx.WorkflowJobTemplateNode
+	if r.stepService != nil {
+		result = r.createStep(&data, &resp.Diagnostics)
+	} else {
+		result = r.createOrAdopt(&data, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, result.ID, &data, &resp.State)...)
+}
+
+// createStep creates a node started after data.WorkflowJobTemplateNodeID.
+func (r *workflowJobTemplateNodeResource) createStep(data *workflowJobTemplateNodeResourceModel, diags *fwdiag.Diagnostics) *awx.WorkflowJobTemplateNode {
+	awxService := r.stepService(r.client)
+	result, err := awxService.CreateWorkflowJobTemplateNodeStep(int(data.WorkflowJobTemplateNodeID.ValueInt64()), data.apiParams(), map[string]string{})
+	if err != nil {
+		log.Printf("Fail to Create Template %v", err)
+		appendSDKDiagnostics(diags, buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplateNode",
+			err,
+			"WorkflowJobTemplateNode with JobTemplateID %d after node %d failed to create", data.UnifiedJobTemplateID.ValueInt64(), data.WorkflowJobTemplateNodeID.ValueInt64(),
+		))
+	}
+	return result
+}
+
+// createOrAdopt creates a node started by the workflow, or takes over the one
+// with the same identifier when the provider adopts existing objects.
+func (r *workflowJobTemplateNodeResource) createOrAdopt(data *workflowJobTemplateNodeResourceModel, diags *fwdiag.Diagnostics) *awx.WorkflowJobTemplateNode {
+	awxService := r.client.WorkflowJobTemplateNodeService
+	id, adoptDiags := findExistingObject(r.client, "workflow_job_template_nodes", map[string]interface{}{
+		"identifier":            data.Identifier.ValueString(),
+		"workflow_job_template": int(data.WorkflowJobTemplateID.ValueInt64()),
+	})
+	appendSDKDiagnostics(diags, adoptDiags)
+	if adoptDiags.HasError() {
+		return nil
+	}
+
+	if id != 0 {
+		result, err := awxService.UpdateWorkflowJobTemplateNode(id, data.apiParams(), map[string]string{})
+		if err != nil {
+			appendSDKDiagnostics(diags, buildDiagAPIFail(
+				"Unable to update WorkflowJobTemplateNode",
+				err,
+				"WorkflowJobTemplateNode with id %d failed to update", id,
+			))
+		}
+		return result
+	}
+
+	result, err := awxService.CreateWorkflowJobTemplateNode(data.apiParams(), map[string]string{})
+	if err != nil {
+		log.Printf("Fail to Create Template %v", err)
+		appendSDKDiagnostics(diags, buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplateNode",
+			err,
+			"WorkflowJobTemplateNode with JobTemplateID %d and WorkflowID: %d failed to create", data.UnifiedJobTemplateID.ValueInt64(), data.WorkflowJobTemplateID.ValueInt64(),
+		))
+	}
+	return result
+}
+
+func (r *workflowJobTemplateNodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data workflowJobTemplateNodeResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, diags := convertIDToNummeric("Read WorkflowJobTemplateNode", data.ID.ValueString())
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, id, &data, &resp.State)...)
+}
+
+func (r *workflowJobTemplateNodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data workflowJobTemplateNodeResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.Plan, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, diags := convertIDToNummeric("Update WorkflowJobTemplateNode", data.ID.ValueString())
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	awxService := r.client.WorkflowJobTemplateNodeService
+	_, err := awxService.GetWorkflowJobTemplateNodeByID(id, make(map[string]string))
+	if err != nil {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagNotFoundFail("workflow job template node", id, err))
+		return
+	}
+
+	_, err = awxService.UpdateWorkflowJobTemplateNode(id, data.apiParams(), map[string]string{})
+	if err != nil {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagAPIFail(
+			"Unable to update WorkflowJobTemplateNode",
+			err,
+			"WorkflowJobTemplateNode with id %d failed to update", id,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, id, &data, &resp.State)...)
+}
+
+func (r *workflowJobTemplateNodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data workflowJobTemplateNodeResourceModel
+	resp.Diagnostics.Append(r.getModel(ctx, req.State, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, diags := convertIDToNummeric("Delete WorkflowJobTemplateNode", data.ID.ValueString())
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.WorkflowJobTemplateNodeService.DeleteWorkflowJobTemplateNode(id); err != nil {
+		appendSDKDiagnostics(&resp.Diagnostics, buildDiagDeleteFail(
+			"WorkflowJobTemplateNode",
+			fmt.Sprintf("id %v, got %s ", id, err.Error()),
+		))
+	}
+}
+
+// read refreshes data from the node id and writes it to state. A node
+// deleted outside of Terraform is removed from the state.
+func (r *workflowJobTemplateNodeResource) read(ctx context.Context, id int, data *workflowJobTemplateNodeResourceModel, state *tfsdk.State) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	res := new(workflowJobTemplateNode)
+	err := r.client.getJSON(fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/", id), res, map[string]string{})
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] workflow job template node %d not found, removing it from the state", id)
+			state.RemoveResource(ctx)
+			return diags
+		}
+		appendSDKDiagnostics(&diags, buildDiagNotFoundFail("workflow job template node", id, err))
+		return diags
+	}
+
+	data.setFromAPI(res)
+	return r.setModel(ctx, state, data)
+}
+
+// getModel reads a plan or a state into data. The schema of the steps has a
+// workflow_job_template_node_id the model can not hold, which goes to
+// data.WorkflowJobTemplateNodeID.
+func (r *workflowJobTemplateNodeResource) getModel(ctx context.Context, src modelGetter, data *workflowJobTemplateNodeResourceModel) fwdiag.Diagnostics {
+	if r.stepService == nil {
+		return src.Get(ctx, data)
+	}
+
+	parentID, diags := getModelWithout(ctx, src, "workflow_job_template_node_id", data)
+	data.WorkflowJobTemplateNodeID = parentID
+	return diags
+}
+
+// setModel writes data to state, the reverse of getModel.
+func (r *workflowJobTemplateNodeResource) setModel(ctx context.Context, state *tfsdk.State, data *workflowJobTemplateNodeResourceModel) fwdiag.Diagnostics {
+	if r.stepService == nil {
+		return state.Set(ctx, data)
+	}
+	return setModelWith(ctx, state, data, "workflow_job_template_node_id", data.WorkflowJobTemplateNodeID)
+}
+
+func (data *workflowJobTemplateNodeResourceModel) apiParams() map[string]interface{} {
+	// extra_data is an object, the text is sent as it is when it does not
+	// decode for AWX to report the error
+	var extraData interface{} = variablesText(data.ExtraData, data.ExtraDataMap)
+	if variables, err := decodeVariables(extraData.(string)); err == nil {
+		extraData = variables
+	}
+	return map[string]interface{}{
+		"extra_data":                extraData,
+		"inventory":                 IntpOrNil(int(data.InventoryID.ValueInt64())),
+		"scm_branch":                data.ScmBranch.ValueString(),
+		"skip_tags":                 data.SkipTags.ValueString(),
+		"job_type":                  data.JobType.ValueString(),
+		"job_tags":                  data.JobTags.ValueString(),
+		"limit":                     data.Limit.ValueString(),
+		"diff_mode":                 data.DiffMode.ValueBool(),
+		"verbosity":                 data.Verbosity.ValueInt64(),
+		"workflow_job_template":     data.WorkflowJobTemplateID.ValueInt64(),
+		"unified_job_template":      data.UnifiedJobTemplateID.ValueInt64(),
+		"all_parents_must_converge": data.AllParentsMustConverge.ValueBool(),
+		"identifier":                data.Identifier.ValueString(),
+	}
+}
+
+// workflowJobTemplateNode is a node as AWX returns it. Unlike the goawx type,
+// extra_data is an object and diff_mode a boolean, AWX leaves the prompts it
+// does not apply null.
+type workflowJobTemplateNode struct {
+	ID                     int                    `json:"id"`
+	ExtraData              map[string]interface{} `json:"extra_data"`
+	Inventory              int                    `json:"inventory"`
+	ScmBranch              string                 `json:"scm_branch"`
+	JobType                string                 `json:"job_type"`
+	JobTags                string                 `json:"job_tags"`
+	SkipTags               string                 `json:"skip_tags"`
+	Limit                  string                 `json:"limit"`
+	DiffMode               bool                   `json:"diff_mode"`
+	Verbosity              int                    `json:"verbosity"`
+	WorkflowJobTemplate    int                    `json:"workflow_job_template"`
+	UnifiedJobTemplate     int                    `json:"unified_job_template"`
+	AllParentsMustConverge bool                   `json:"all_parents_must_converge"`
+	Identifier             string                 `json:"identifier"`
+}
+
+func (data *workflowJobTemplateNodeResourceModel) setFromAPI(r *workflowJobTemplateNode) {
+	extraData := ""
+	if len(r.ExtraData) > 0 {
+		encoded, err := json.Marshal(r.ExtraData)
+		if err != nil {
+			log.Printf("[WARN] unable to encode the extra_data of node %d: %s", r.ID, err)
+		}
+		extraData = string(encoded)
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(r.ID))
+	setVariablesText(&data.ExtraData, &data.ExtraDataMap, extraData)
+	data.InventoryID = types.Int64Value(int64(r.Inventory))
+	data.ScmBranch = types.StringValue(r.ScmBranch)
+	data.JobType = types.StringValue(r.JobType)
+	data.JobTags = types.StringValue(r.JobTags)
+	data.SkipTags = types.StringValue(r.SkipTags)
+	data.Limit = types.StringValue(r.Limit)
+	data.DiffMode = types.BoolValue(r.DiffMode)
+	data.Verbosity = types.Int64Value(int64(r.Verbosity))
+	data.WorkflowJobTemplateID = types.Int64Value(int64(r.WorkflowJobTemplate))
+	data.UnifiedJobTemplateID = types.Int64Value(int64(r.UnifiedJobTemplate))
+	data.AllParentsMustConverge = types.BoolValue(r.AllParentsMustConverge)
+	data.Identifier = types.StringValue(r.Identifier)
 }
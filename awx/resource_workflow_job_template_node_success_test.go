@@ -0,0 +1,75 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceWorkflowJobTemplateNodeSuccess(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml", "inventory": inventoryID})
+	workflowJobTemplateID := server.Add("workflow_job_templates", map[string]interface{}{"name": "workflow", "organization": orgID})
+	parentID := server.Add("workflow_job_template_nodes", map[string]interface{}{"workflow_job_template": workflowJobTemplateID, "unified_job_template": jobTemplateID, "identifier": "parent"})
+	config := func(limit string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template_node_success" "test" {
+  workflow_job_template_id      = %d
+  workflow_job_template_node_id = %d
+  unified_job_template_id       = %d
+  identifier                    = "test"
+  limit                         = %q
+}
+`, workflowJobTemplateID, parentID, jobTemplateID, limit))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "workflow_job_template_nodes", "awx_workflow_job_template_node_success"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("web"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "workflow_job_template_nodes", "awx_workflow_job_template_node_success.test"),
+					func(s *terraform.State) error {
+						nodeID := testObjectID(t, server, "workflow_job_template_nodes", "identifier", "test")
+						if associated := server.Associated("workflow_job_template_nodes", parentID, "success_nodes"); fmt.Sprint(associated) != fmt.Sprint([]int{nodeID}) {
+							return fmt.Errorf("success_nodes of node %d are %v, expected [%d]", parentID, associated, nodeID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node_success.test",
+				ImportState:       true,
+				ImportStateId:     "parent++workflow++org:test++workflow++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("db"),
+				Check:  testCheckField(server, "workflow_job_template_nodes", "awx_workflow_job_template_node_success.test", "limit", "db"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "workflow_job_template_nodes", "identifier", "test", map[string]interface{}{"limit": "changed"}),
+				Config:             config("db"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("db"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "workflow_job_template_nodes", "identifier", "test"),
+				Config:             config("db"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
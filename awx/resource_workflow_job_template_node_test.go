@@ -0,0 +1,113 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceWorkflowJobTemplateNode(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml", "inventory": inventoryID})
+	workflowJobTemplateID := server.Add("workflow_job_templates", map[string]interface{}{"name": "workflow", "organization": orgID})
+	config := func(limit string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template_node" "test" {
+  workflow_job_template_id = %d
+  unified_job_template_id  = %d
+  inventory_id             = %d
+  identifier               = "test"
+  limit                    = %q
+}
+`, workflowJobTemplateID, jobTemplateID, inventoryID, limit))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "workflow_job_template_nodes", "awx_workflow_job_template_node"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "workflow_job_template_nodes", "awx_workflow_job_template_node.test"),
+					testCheckField(server, "workflow_job_template_nodes", "awx_workflow_job_template_node.test", "unified_job_template", jobTemplateID),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node.test",
+				ImportState:       true,
+				ImportStateId:     "test++workflow++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "workflow_job_template_nodes", "awx_workflow_job_template_node.test", "limit", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "workflow_job_template_nodes", "identifier", "test", map[string]interface{}{"limit": "changed"}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "workflow_job_template_nodes", "identifier", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceWorkflowJobTemplateNodeExtraDataMap(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	inventoryID := server.Add("inventories", map[string]interface{}{"name": "inventory", "organization": orgID})
+	projectID := server.Add("projects", map[string]interface{}{"name": "project", "organization": orgID})
+	jobTemplateID := server.Add("job_templates", map[string]interface{}{"name": "job", "project": projectID, "playbook": "site.yml", "inventory": inventoryID})
+	workflowJobTemplateID := server.Add("workflow_job_templates", map[string]interface{}{"name": "workflow", "organization": orgID})
+	config := func(release string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template_node" "test" {
+  workflow_job_template_id = %d
+  unified_job_template_id  = %d
+  identifier               = "test"
+
+  extra_data_map = {
+    release = %q
+    debug   = true
+  }
+}
+`, workflowJobTemplateID, jobTemplateID, release))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "workflow_job_template_nodes", "awx_workflow_job_template_node"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("stable"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "extra_data", ""),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "extra_data_map.debug", "true"),
+				),
+			},
+			{
+				Config: config("edge"),
+				Check:  resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "extra_data_map.release", "edge"),
+			},
+		},
+	})
+}
@@ -10,6 +10,14 @@ resource "awx_workflow_job_template_notification_template_error" "baseconfig" {
 }
 ```
 
+Import
+
+The ID is `<workflow_job_template_id>:<notification_template_id>`.
+
+```shell
+terraform import awx_workflow_job_template_notification_template_error.baseconfig 12:5
+```
+
 */
 package awx
 
@@ -21,7 +29,15 @@ func resourceWorkflowJobTemplateNotificationTemplateError() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateNotificationTemplateCreateForType("error"),
 		DeleteContext: resourceWorkflowJobTemplateNotificationTemplateDeleteForType("error"),
-		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateReadForType("error"),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCompositeID("workflow_job_template_id", "notification_template_id"),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			compositeIDStateUpgrader("workflow_job_template_id", "notification_template_id"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"workflow_job_template_id": {
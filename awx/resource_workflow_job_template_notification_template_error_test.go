@@ -0,0 +1,68 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceWorkflowJobTemplateNotificationTemplateError(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	workflowJobTemplateID := server.Add("workflow_job_templates", map[string]interface{}{"name": "workflow", "organization": orgID})
+	notificationTemplateID := server.Add("notification_templates", map[string]interface{}{"name": "notify", "organization": orgID, "notification_type": "slack"})
+	config := testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template_notification_template_error" "test" {
+  workflow_job_template_id = %d
+  notification_template_id = %d
+}
+`, workflowJobTemplateID, notificationTemplateID))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if associated := server.Associated("workflow_job_templates", workflowJobTemplateID, "notification_templates_error"); len(associated) != 0 {
+				return fmt.Errorf("notification_templates_error of workflow_job_templates %d are still %v", workflowJobTemplateID, associated)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_notification_template_error.test", "id", fmt.Sprintf("%d:%d", workflowJobTemplateID, notificationTemplateID)),
+					func(*terraform.State) error {
+						if associated := server.Associated("workflow_job_templates", workflowJobTemplateID, "notification_templates_error"); fmt.Sprint(associated) != fmt.Sprint([]int{notificationTemplateID}) {
+							return fmt.Errorf("notification_templates_error of workflow_job_templates %d are %v", workflowJobTemplateID, associated)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_notification_template_error.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_notification_template_error.test",
+				ImportState:       true,
+				ImportStateId:     "workflow++org:notify++org",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					server.Disassociate("workflow_job_templates", workflowJobTemplateID, "notification_templates_error", notificationTemplateID)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+			},
+		},
+	})
+}
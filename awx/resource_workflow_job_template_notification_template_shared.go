@@ -2,7 +2,7 @@ package awx
 
 import (
 	"context"
-	"strconv"
+	"fmt"
 
 	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -36,7 +36,7 @@ func getResourceWorkflowJobTemplateNotificationTemplateDisassociateFuncForType(c
 func resourceWorkflowJobTemplateNotificationTemplateCreateForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		var diags diag.Diagnostics
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		awxWorkflowJobTemplateService := client.WorkflowJobTemplateService
 		workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
 		_, err := awxWorkflowJobTemplateService.GetWorkflowJobTemplateByID(workflowJobTemplateID, make(map[string]string))
@@ -51,25 +51,27 @@ func resourceWorkflowJobTemplateNotificationTemplateCreateForType(typ string) fu
 			return buildDiagnosticsMessage("Create: WorkflowJobTemplate not AssociateWorkflowJobTemplateNotificationTemplates", "Fail to find association function for notification_template type %s", typ)
 		}
 
-		result, err := associationFunc(workflowJobTemplateID, notificationTemplateID)
+		_, err = associationFunc(workflowJobTemplateID, notificationTemplateID)
 		if err != nil {
 			return buildDiagnosticsMessage("Create: WorkflowJobTemplate not AssociateWorkflowJobTemplateNotificationTemplates", "Fail to associate notification_template credentials with ID %v, for workflow_job_template ID %v, got error: %s", notificationTemplateID, workflowJobTemplateID, err.Error())
 		}
 
-		d.SetId(strconv.Itoa(result.ID))
+		d.SetId(buildCompositeID(workflowJobTemplateID, notificationTemplateID))
 		return diags
 	}
 }
 
-func resourceWorkflowJobTemplateNotificationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+func resourceWorkflowJobTemplateNotificationTemplateReadForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		endpoint := fmt.Sprintf("/api/v2/workflow_job_templates/%d/notification_templates_%s/", d.Get("workflow_job_template_id").(int), typ)
+		return readAssociation(d, m, "WorkflowJobTemplate NotificationTemplate", endpoint, "notification_template_id")
+	}
 }
 
 func resourceWorkflowJobTemplateNotificationTemplateDeleteForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		var diags diag.Diagnostics
-		client := m.(*awx.AWX)
+		client := m.(*awxClient)
 		awxWorkflowJobTemplateService := client.WorkflowJobTemplateService
 		workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
 		_, err := awxWorkflowJobTemplateService.GetWorkflowJobTemplateByID(workflowJobTemplateID, make(map[string]string))
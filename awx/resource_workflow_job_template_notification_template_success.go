@@ -10,6 +10,14 @@ resource "awx_workflow_job_template_notification_template_success" "baseconfig"
 }
 ```
 
+Import
+
+The ID is `<workflow_job_template_id>:<notification_template_id>`.
+
+```shell
+terraform import awx_workflow_job_template_notification_template_success.baseconfig 12:5
+```
+
 */
 package awx
 
@@ -21,7 +29,15 @@ func resourceWorkflowJobTemplateNotificationTemplateSuccess() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateNotificationTemplateCreateForType("success"),
 		DeleteContext: resourceWorkflowJobTemplateNotificationTemplateDeleteForType("success"),
-		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateReadForType("success"),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateCompositeID("workflow_job_template_id", "notification_template_id"),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			compositeIDStateUpgrader("workflow_job_template_id", "notification_template_id"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"workflow_job_template_id": {
@@ -20,11 +20,9 @@ package awx
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"strconv"
 
-	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -53,6 +51,7 @@ func resourceWorkflowJobTemplateSchedule() *schema.Resource {
 			"unified_job_template_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -64,45 +63,82 @@ func resourceWorkflowJobTemplateSchedule() *schema.Resource {
 				Default:  true,
 			},
 			"inventory": {
-				Type:        schema.TypeString,
+				Type:        schema.TypeInt,
 				Optional:    true,
 				Description: "Inventory applied as a prompt, assuming job template prompts for inventory (id, default=``)",
 			},
 			"extra_data": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Extra data to be pass for the schedule (YAML format)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				Description:      "Extra data to be pass for the schedule (JSON or YAML format)",
+				ValidateDiagFunc: validateJSONOrYAMLObject,
+				DiffSuppressFunc: suppressVariablesDiff,
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			numericIDStateUpgrader(0, "inventory"),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWorkflowJobTemplateScheduleImport,
+		},
 	}
 }
 
 func resourceWorkflowJobTemplateScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
+	if id, diags := findExistingObject(m, "schedules", map[string]interface{}{
+		"name":                 d.Get("name").(string),
+		"unified_job_template": d.Get("workflow_job_template_id").(int),
+	}); diags.HasError() {
+		return diags
+	} else if id != 0 {
+		d.SetId(strconv.Itoa(id))
+		return resourceScheduleUpdate(ctx, d, m)
+	}
+
+	client := m.(*awxClient)
 	awxService := client.WorkflowJobTemplateScheduleService
 
 	workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
+	extraData, diags := getVariables(d, "extra_data")
+	if diags.HasError() {
+		return diags
+	}
 
 	result, err := awxService.CreateWorkflowJobTemplateSchedule(workflowJobTemplateID, map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"rrule":       d.Get("rrule").(string),
 		"description": d.Get("description").(string),
 		"enabled":     d.Get("enabled").(bool),
-		"inventory":   AtoipOr(d.Get("inventory").(string), nil),
-		"extra_data":  unmarshalYaml(d.Get("extra_data").(string)),
+		"inventory":   IntpOrNil(d.Get("inventory").(int)),
+		"extra_data":  extraData,
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Schedule for WorkflowJobTemplate %d: %v", workflowJobTemplateID, err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Schedule",
-			Detail:   fmt.Sprintf("Schedule failed to create %s", err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create Schedule",
+			err,
+			"Schedule failed to create",
+		)...)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
 	return resourceScheduleRead(ctx, d, m)
 }
+
+func resourceWorkflowJobTemplateScheduleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*awxClient)
+	id, err := resolveIDOrNamedURL(client, "schedules", d.Id())
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ScheduleService.GetByID(id, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	// the schedule of a workflow runs the workflow itself
+	d.Set("workflow_job_template_id", res.UnifiedJobTemplate)
+	d.SetId(strconv.Itoa(id))
+	return []*schema.ResourceData{d}, nil
+}
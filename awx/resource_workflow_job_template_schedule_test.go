@@ -0,0 +1,68 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceWorkflowJobTemplateSchedule(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	workflowJobTemplateID := server.Add("workflow_job_templates", map[string]interface{}{"name": "workflow", "organization": orgID})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template_schedule" "test" {
+  name                     = "test"
+  description              = %q
+  rrule                    = "DTSTART;TZID=UTC:20230101T000000 RRULE:FREQ=DAILY;INTERVAL=1"
+  workflow_job_template_id = %d
+}
+`, description, workflowJobTemplateID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "schedules", "awx_workflow_job_template_schedule"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "schedules", "awx_workflow_job_template_schedule.test"),
+					testCheckField(server, "schedules", "awx_workflow_job_template_schedule.test", "unified_job_template", workflowJobTemplateID),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_schedule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_schedule.test",
+				ImportState:       true,
+				ImportStateId:     "test++workflow++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "schedules", "awx_workflow_job_template_schedule.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "schedules", "name", "test", map[string]interface{}{"enabled": false}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "schedules", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+/*
+*TBD*
+
+Example Usage
+
+```hcl
+resource "awx_workflow_job_template_survey_spec" "default" {
+  workflow_job_template_id = awx_workflow_job_template.default.id
+
+  question {
+    variable      = "limit_hosts"
+    question_name = "Hosts to run on"
+    type          = "textarea"
+    default       = "all"
+  }
+
+  question {
+    variable      = "batch_size"
+    question_name = "Batch size"
+    type          = "integer"
+    default       = "5"
+    min           = 1
+    max           = 50
+  }
+}
+```
+
+The survey is only prompted for when `survey_enabled` is set on the workflow job template.
+
+Import
+
+Surveys can be imported by the ID or the AWX named URL of their workflow job template, `<name>++<organization>`.
+
+```shell
+terraform import awx_workflow_job_template_survey_spec.default 'default++Default'
+```
+
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func newWorkflowJobTemplateSurveySpecResource() resource.Resource {
+	return &surveySpecResource{
+		typeSuffix:        "_workflow_job_template_survey_spec",
+		collection:        "workflow_job_templates",
+		templateAttribute: "workflow_job_template_id",
+	}
+}
@@ -0,0 +1,77 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceWorkflowJobTemplateSurveySpec(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	workflowJobTemplateID := server.Add("workflow_job_templates", map[string]interface{}{"name": "workflow", "organization": orgID})
+	config := func(defaultValue string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template_survey_spec" "test" {
+  workflow_job_template_id = %d
+
+  question {
+    variable      = "ratio"
+    question_name = "Ratio"
+    type          = "float"
+    default       = %q
+  }
+
+  question {
+    variable      = "targets"
+    question_name = "Targets"
+    type          = "multiselect"
+    choices       = ["web", "db", "cache"]
+    default       = "web\ndb"
+  }
+}
+`, workflowJobTemplateID, defaultValue))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckSurveyDestroyed(server, "workflow_job_templates", workflowJobTemplateID),
+		Steps: []resource.TestStep{
+			{
+				// AWX gives back 0.5, which is not a change
+				Config: config("0.50"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSurveyQuestion(server, "workflow_job_templates", "awx_workflow_job_template_survey_spec.test", 0, "default", 0.5),
+					testCheckSurveyQuestion(server, "workflow_job_templates", "awx_workflow_job_template_survey_spec.test", 1, "default", "web\ndb"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_survey_spec.test", "question.0.default", "0.50"),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_survey_spec.test",
+				ImportState:       true,
+				ImportStateId:     "workflow++org",
+				ImportStateVerify: true,
+				// the default is imported as AWX gives it back
+				ImportStateVerifyIgnore: []string{"question.0.default"},
+			},
+			{
+				Config: config("0.75"),
+				Check:  testCheckSurveyQuestion(server, "workflow_job_templates", "awx_workflow_job_template_survey_spec.test", 0, "default", 0.75),
+			},
+			{
+				PreConfig: func() {
+					spec := server.Survey("workflow_job_templates", workflowJobTemplateID)
+					spec["spec"].([]interface{})[1].(map[string]interface{})["choices"] = "web\ndb"
+					server.SetSurvey("workflow_job_templates", workflowJobTemplateID, spec)
+				},
+				Config:             config("0.75"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("0.75"),
+			},
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceWorkflowJobTemplate(t *testing.T) {
+	server := newTestServer(t)
+	orgID := server.Add("organizations", map[string]interface{}{"name": "org"})
+	credentialID := server.Add("credentials", map[string]interface{}{"name": "github", "credential_type": 13, "organization": orgID})
+	config := func(description string) string {
+		return testProviderConfig(server, fmt.Sprintf(`
+resource "awx_workflow_job_template" "test" {
+  name                    = "test"
+  description             = %q
+  organization_id         = %d
+  ask_limit_on_launch     = true
+  variables               = "foo: bar"
+  webhook_service         = "github"
+  webhook_credential      = %d
+}
+`, description, orgID, credentialID))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testProtoV5ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "workflow_job_templates", "awx_workflow_job_template"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("created"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExists(server, "workflow_job_templates", "awx_workflow_job_template.test"),
+					testCheckField(server, "workflow_job_templates", "awx_workflow_job_template.test", "ask_limit_on_launch", true),
+					testCheckField(server, "workflow_job_templates", "awx_workflow_job_template.test", "webhook_credential", credentialID),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_workflow_job_template.test",
+				ImportState:       true,
+				ImportStateId:     "test++org",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("updated"),
+				Check:  testCheckField(server, "workflow_job_templates", "awx_workflow_job_template.test", "description", "updated"),
+			},
+			{
+				PreConfig:          testUpdateObject(t, server, "workflow_job_templates", "name", "test", map[string]interface{}{"ask_limit_on_launch": false}),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("updated"),
+			},
+			{
+				PreConfig:          testDeleteObject(t, server, "workflow_job_templates", "name", "test"),
+				Config:             config("updated"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,342 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// The cassette transport is a test helper: when AWX_CASSETTE names a file, the
+// calls the provider sends to the AWX API are recorded to it, or replayed
+// from it without reaching any AWX. Cassettes recorded once against a real
+// AWX release let the tests check the provider against that release offline.
+const (
+	cassetteEnv     = "AWX_CASSETTE"
+	cassetteModeEnv = "AWX_CASSETTE_MODE"
+
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+)
+
+// cassette holds the interactions of a cassette file. Terraform configures
+// the provider again for each command, every configured transport shares the
+// cassette of its file.
+type cassette struct {
+	mu       sync.Mutex
+	path     string
+	record   bool
+	replayed map[int]bool
+
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteMessage `json:"request"`
+	Response cassetteMessage `json:"response"`
+}
+
+// cassetteMessage is a request or a response. JSON bodies are kept as they
+// are to keep cassettes readable, other bodies as text.
+type cassetteMessage struct {
+	Method      string          `json:"method,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Status      int             `json:"status,omitempty"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	Text        string          `json:"text,omitempty"`
+}
+
+var cassettes = struct {
+	sync.Mutex
+	byPath map[string]*cassette
+}{byPath: make(map[string]*cassette)}
+
+// newCassetteTransport wraps next with the cassette transport when
+// AWX_CASSETTE is set, AWX_CASSETTE_MODE being `replay`, the default, or
+// `record`. Recording starts a new cassette, replacing the file.
+func newCassetteTransport(next http.RoundTripper) (http.RoundTripper, error) {
+	path := os.Getenv(cassetteEnv)
+	if path == "" {
+		return next, nil
+	}
+	mode := os.Getenv(cassetteModeEnv)
+	if mode == "" {
+		mode = cassetteModeReplay
+	}
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		return nil, fmt.Errorf("invalid %s %q, expected %s or %s", cassetteModeEnv, mode, cassetteModeRecord, cassetteModeReplay)
+	}
+
+	c, err := loadCassette(path, mode == cassetteModeRecord)
+	if err != nil {
+		return nil, err
+	}
+	if c.record {
+		return &cassetteTransport{next: next, cassette: c}, nil
+	}
+	return &cassetteTransport{cassette: c}, nil
+}
+
+func loadCassette(path string, record bool) (*cassette, error) {
+	cassettes.Lock()
+	defer cassettes.Unlock()
+
+	if c, ok := cassettes.byPath[path]; ok && c.record == record {
+		return c, nil
+	}
+	c := &cassette{path: path, record: record, replayed: make(map[int]bool)}
+	if record {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+	} else {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the cassette: %w", err)
+		}
+		if err := json.Unmarshal(content, c); err != nil {
+			return nil, fmt.Errorf("unable to parse the cassette %s: %w", path, err)
+		}
+	}
+	cassettes.byPath[path] = c
+	return c, nil
+}
+
+// forgetCassette drops the cassette of path, so that it is read again and
+// replayed from its first interaction.
+func forgetCassette(path string) {
+	cassettes.Lock()
+	defer cassettes.Unlock()
+	delete(cassettes.byPath, path)
+}
+
+// cassetteTransport records the calls to next, or replays them when next is
+// nil.
+type cassetteTransport struct {
+	next     http.RoundTripper
+	cassette *cassette
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	// only the path and query are kept, never the host nor the credentials
+	request := cassetteMessage{Method: req.Method, URL: req.URL.RequestURI()}
+	setCassetteBody(&request, redactCassetteBody(body, req.Header.Get("Content-Type"), true))
+
+	if t.next == nil {
+		return t.cassette.replay(req, request)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	response := cassetteMessage{Status: resp.StatusCode, ContentType: resp.Header.Get("Content-Type")}
+	setCassetteBody(&response, redactCassetteBody(respBody, response.ContentType, false))
+	if err := t.cassette.add(&cassetteInteraction{Request: request, Response: response}); err != nil {
+		return nil, fmt.Errorf("unable to record the cassette: %w", err)
+	}
+	return resp, nil
+}
+
+func setCassetteBody(m *cassetteMessage, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	var compacted bytes.Buffer
+	if json.Compact(&compacted, body) == nil {
+		m.Body = compacted.Bytes()
+	} else {
+		m.Text = string(body)
+	}
+}
+
+// cassetteSecret replaces the secrets of the recorded bodies, as AWX answers
+// in place of the ones it stores.
+const cassetteSecret = "$encrypted$"
+
+// cassetteSecretKeys are the fields holding a secret, at any depth of a body:
+// the passwords, and the OAuth2 client secrets and tokens.
+var cassetteSecretKeys = map[string]bool{
+	"password":      true,
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+	"token":         true,
+}
+
+// redactCassetteBody replaces the secrets of a JSON or form body before it is
+// recorded. Requests being redacted alike before they are matched, a
+// redacted cassette still replays.
+func redactCassetteBody(body []byte, contentType string, request bool) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if decoder.Decode(&v) == nil {
+		if redacted, err := json.Marshal(redactCassetteValue(v, request)); err == nil {
+			return redacted
+		}
+		return body
+	}
+	// the OAuth2 endpoints take forms
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for key := range form {
+				if cassetteSecretKeys[key] {
+					form.Set(key, cassetteSecret)
+				}
+			}
+			return []byte(form.Encode())
+		}
+	}
+	return body
+}
+
+// redactCassetteValue replaces the secrets of a decoded JSON value. The
+// inputs of the credentials sent are all replaced, while the inputs AWX
+// answers only lose the secret looking ones: AWX already hides the others
+// and the usernames it shows are needed to check the state built from them.
+func redactCassetteValue(v interface{}, request bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch {
+			case cassetteSecretKeys[key]:
+				if value != nil && value != "" {
+					v[key] = cassetteSecret
+				}
+			case key == "inputs":
+				v[key] = redactCassetteInputs(value, request)
+			default:
+				v[key] = redactCassetteValue(value, request)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactCassetteValue(value, request)
+		}
+	}
+	return v
+}
+
+func redactCassetteInputs(v interface{}, request bool) interface{} {
+	inputs, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	for key, value := range inputs {
+		if value == nil || value == "" {
+			continue
+		}
+		if request || isSecretInput(key) {
+			inputs[key] = cassetteSecret
+		}
+	}
+	return inputs
+}
+
+// isSecretInput reports whether a credential input holds a secret, as
+// password, ssh_key_data or secret_key.
+func isSecretInput(key string) bool {
+	for _, part := range []string{"password", "secret", "token", "key_data", "key_unlock"} {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// body returns the body of m, JSON being compacted as cassettes are saved
+// indented.
+func (m *cassetteMessage) body() []byte {
+	if len(m.Body) > 0 {
+		var compacted bytes.Buffer
+		if json.Compact(&compacted, m.Body) == nil {
+			return compacted.Bytes()
+		}
+		return m.Body
+	}
+	return []byte(m.Text)
+}
+
+// add records an interaction, saving the cassette right away as the plugin
+// may be stopped at any time.
+func (c *cassette) add(interaction *cassetteInteraction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Interactions = append(c.Interactions, interaction)
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(content, '\n'), 0o644)
+}
+
+// replay answers with the first interaction not replayed yet recorded for
+// the same request. Once all are replayed the last one answers again, the
+// provider polling or reading an object more often than when recording.
+func (c *cassette) replay(req *http.Request, request cassetteMessage) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+	for i, interaction := range c.Interactions {
+		if interaction.Request.Method != request.Method || interaction.Request.URL != request.URL ||
+			!bytes.Equal(interaction.Request.body(), request.body()) {
+			continue
+		}
+		match = i
+		if !c.replayed[i] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, fmt.Errorf("no interaction recorded for %s %s in %s", request.Method, request.URL, c.path)
+	}
+	c.replayed[match] = true
+
+	response := c.Interactions[match].Response
+	header := make(http.Header)
+	if response.ContentType != "" {
+		header.Set("Content-Type", response.ContentType)
+	}
+	body := response.body()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
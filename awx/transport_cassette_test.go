@@ -0,0 +1,106 @@
+package awx
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassetteTransport(t *testing.T) {
+	server := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	t.Setenv(cassetteEnv, path)
+	t.Cleanup(func() {
+		forgetCassette(path)
+	})
+
+	send := func(transport http.RoundTripper, method, url, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer test")
+		req.Header.Set("Content-Type", "application/json")
+		if strings.Contains(url, "/o/") {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(content)
+	}
+
+	t.Setenv(cassetteModeEnv, cassetteModeRecord)
+	recorder, err := newCassetteTransport(http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createdStatus, created := send(recorder, http.MethodPost, server.URL+"/api/v2/organizations/", `{"name": "recorded"}`)
+	_, listed := send(recorder, http.MethodGet, server.URL+"/api/v2/organizations/?name=recorded", "")
+	credential := `{"name": "recorded", "credential_type": 1, "inputs": {"username": "root", "password": "top-secret"}}`
+	_, createdCredential := send(recorder, http.MethodPost, server.URL+"/api/v2/credentials/", credential)
+	send(recorder, http.MethodPost, server.URL+"/api/o/token/", "grant_type=password&username=root&password=top-secret")
+	server.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "top-secret") {
+		t.Errorf("the cassette holds a secret:\n%s", content)
+	}
+	if !strings.Contains(string(content), `"username": "root"`) {
+		t.Errorf("the cassette lost the username of the credential:\n%s", content)
+	}
+
+	forgetCassette(path)
+	t.Setenv(cassetteModeEnv, cassetteModeReplay)
+	player, err := newCassetteTransport(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the body is matched whatever its formatting, and the host is not
+	if status, content := send(player, http.MethodPost, "https://awx.invalid/api/v2/organizations/", `{"name":"recorded"}`); status != createdStatus || content != compactJSON(t, created) {
+		t.Errorf("replayed %d %s, expected %d %s", status, content, createdStatus, created)
+	}
+	for i := 0; i < 2; i++ {
+		if _, content := send(player, http.MethodGet, "https://awx.invalid/api/v2/organizations/?name=recorded", ""); content != compactJSON(t, listed) {
+			t.Errorf("replayed %s, expected %s", content, listed)
+		}
+	}
+
+	// the secrets are redacted alike before matching
+	if _, content := send(player, http.MethodPost, "https://awx.invalid/api/v2/credentials/", credential); content != string(redactCassetteBody([]byte(createdCredential), "application/json", false)) {
+		t.Errorf("replayed %s, expected %s", content, createdCredential)
+	}
+	if status, _ := send(player, http.MethodPost, "https://awx.invalid/api/o/token/", "grant_type=password&username=root&password=top-secret"); status != http.StatusNotFound {
+		t.Errorf("replayed the token exchange with %d, expected the recorded 404", status)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://awx.invalid/api/v2/organizations/?name=other", nil)
+	if _, err := player.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "no interaction recorded") {
+		t.Errorf("unrecorded request replayed, got %v", err)
+	}
+
+	t.Setenv(cassetteModeEnv, "rewind")
+	if _, err := newCassetteTransport(nil); err == nil {
+		t.Error("invalid mode accepted")
+	}
+}
+
+func compactJSON(t *testing.T, content string) string {
+	t.Helper()
+	m := cassetteMessage{}
+	setCassetteBody(&m, []byte(content))
+	return string(m.body())
+}
@@ -0,0 +1,51 @@
+package awx
+
+import (
+	"math"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// limitTransport paces and caps the calls one provider instance sends to the
+// AWX API. Every resource and data source shares it through the provider HTTP
+// client, and each retry attempt is counted as a call of its own.
+type limitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+func newLimitTransport(next http.RoundTripper, requestsPerSecond float64, maxConcurrent int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return next
+	}
+	t := &limitTransport{next: next}
+	if requestsPerSecond > 0 {
+		burst := int(math.Ceil(requestsPerSecond))
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		// the slot is held until the controller answers, AWX responses are
+		// small enough for the body read not to matter
+		defer func() { <-t.slots }()
+	}
+	if t.limiter != nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return t.next.RoundTrip(req)
+}
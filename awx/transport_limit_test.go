@@ -0,0 +1,158 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingServer answers once release is closed, and tracks how many
+// requests it holds at the same time.
+type blockingServer struct {
+	*httptest.Server
+	release  chan struct{}
+	inFlight int32
+	max      int32
+	received chan struct{}
+}
+
+func newBlockingServer(t *testing.T) *blockingServer {
+	t.Helper()
+	s := &blockingServer{release: make(chan struct{}), received: make(chan struct{}, 100)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&s.inFlight, 1)
+		defer atomic.AddInt32(&s.inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&s.max)
+			if n <= max || atomic.CompareAndSwapInt32(&s.max, max, n) {
+				break
+			}
+		}
+		s.received <- struct{}{}
+		select {
+		case <-s.release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// waitReceived waits for n requests to reach the server.
+func (s *blockingServer) waitReceived(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%d requests received, expected %d", i, n)
+		}
+	}
+}
+
+func TestLimitTransportConcurrency(t *testing.T) {
+	server := newBlockingServer(t)
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	server.waitReceived(t, 2)
+	// the other requests wait for a slot instead of reaching the server
+	select {
+	case <-server.received:
+		t.Error("a third request reached the server while two are in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(server.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if max := atomic.LoadInt32(&server.max); max != 2 {
+		t.Errorf("%d requests in flight at most, expected 2", max)
+	}
+}
+
+func TestLimitTransportCancelled(t *testing.T) {
+	server := newBlockingServer(t)
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 0, 1)}
+
+	// the only slot is held by a request cancelled while in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	inFlight := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		_, err := client.Do(req)
+		inFlight <- err
+	}()
+	server.waitReceived(t, 1)
+
+	// a request waiting for the slot gives up with its context
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+	req, _ := http.NewRequestWithContext(waitCtx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("request waiting for a slot returned %v", err)
+	}
+
+	cancel()
+	if err := <-inFlight; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled request returned %v", err)
+	}
+
+	// the cancelled request released its slot
+	close(server.release)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request after the cancellation returned %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestLimitTransportRate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 20, 0)}
+
+	// the first 20 requests are the burst, the next 10 are paced
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("30 requests at 20 per second sent in %s", elapsed)
+	}
+}
+
+func TestLimitTransportDisabled(t *testing.T) {
+	if transport := newLimitTransport(http.DefaultTransport, 0, 0); transport != http.DefaultTransport {
+		t.Errorf("no limit wraps the transport in %T", transport)
+	}
+}
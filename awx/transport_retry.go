@@ -0,0 +1,161 @@
+package awx
+
+import (
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultMaxRetryWait = 30
+	retryMinWait        = 1 * time.Second
+)
+
+// retryTransport retries AWX API calls failing with a transient error.
+// Idempotent requests are retried on any transport error and on 429/502/503/504
+// answers, other requests only when they never reached the controller.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) http.RoundTripper {
+	if maxRetries <= 0 {
+		return next
+	}
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minWait:    retryMinWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		try := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			try = req.Clone(req.Context())
+			try.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(try)
+		if attempt >= t.maxRetries || !shouldRetryRequest(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed: %s, retrying in %s (%d/%d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		} else {
+			log.Printf("[WARN] %s %s answered %d, retrying in %s (%d/%d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt: the Retry-After header
+// when the controller sends one, an exponential delay with jitter otherwise.
+// Both are capped to maxWait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.minWait << uint(attempt)
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+	// full jitter on the upper half, so concurrent callers spread out
+	half := int64(wait / 2)
+	if half > 0 {
+		wait = time.Duration(half + rand.Int63n(half))
+	}
+	return wait
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func shouldRetryRequest(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the payload cannot be replayed
+		return false
+	}
+	if err != nil {
+		if isIdempotentMethod(req.Method) {
+			return true
+		}
+		return isConnectionError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// the controller refused the call before processing it
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotentMethod(req.Method)
+	}
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isConnectionError reports whether err happened while establishing the
+// connection, meaning the request was never sent to the controller.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	return false
+}
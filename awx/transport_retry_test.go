@@ -0,0 +1,200 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		method   string
+		statuses []int
+		attempts int
+	}{
+		{"GET retried until it succeeds", http.MethodGet, []int{503, 502, 200}, 3},
+		{"GET given up after max retries", http.MethodGet, []int{502, 502, 502, 200}, 3},
+		{"GET not retried on a client error", http.MethodGet, []int{404, 200}, 1},
+		{"DELETE retried on a gateway timeout", http.MethodDelete, []int{504, 204}, 2},
+		{"POST retried on too many requests", http.MethodPost, []int{429, 201}, 2},
+		{"POST not retried once processed", http.MethodPost, []int{503, 201}, 1},
+		{"PATCH not retried once processed", http.MethodPatch, []int{502, 200}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				status := tc.statuses[len(bodies)]
+				bodies = append(bodies, string(body))
+				mu.Unlock()
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &retryTransport{
+				next:       http.DefaultTransport,
+				maxRetries: 2,
+				minWait:    time.Millisecond,
+				maxWait:    10 * time.Millisecond,
+			}}
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader(`{"name":"test"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if len(bodies) != tc.attempts {
+				t.Errorf("sent %d times, expected %d", len(bodies), tc.attempts)
+			}
+			if expected := tc.statuses[tc.attempts-1]; resp.StatusCode != expected {
+				t.Errorf("answered %d, expected %d", resp.StatusCode, expected)
+			}
+			for i, body := range bodies {
+				if body != `{"name":"test"}` {
+					t.Errorf("attempt %d sent %q", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportDisabled(t *testing.T) {
+	if transport := newRetryTransport(http.DefaultTransport, 0, time.Second); transport != http.DefaultTransport {
+		t.Errorf("max_retries 0 wraps the transport in %T", transport)
+	}
+}
+
+func TestRetryTransportCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		next:       http.DefaultTransport,
+		maxRetries: 3,
+		minWait:    time.Millisecond,
+		maxWait:    time.Minute,
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	start := time.Now()
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("cancelled wait returned %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("cancelled wait took %s", elapsed)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	transport := &retryTransport{minWait: time.Second, maxWait: 30 * time.Second}
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	if wait := transport.backoff(0, retryAfter("2")); wait != 2*time.Second {
+		t.Errorf("Retry-After 2 waits %s", wait)
+	}
+	if wait := transport.backoff(0, retryAfter("120")); wait != 30*time.Second {
+		t.Errorf("Retry-After 120 waits %s, expected the 30s cap", wait)
+	}
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		wait := transport.backoff(attempt, retryAfter("soon"))
+		if wait < max/2 || wait >= max {
+			t.Errorf("attempt %d waits %s, expected between %s and %s", attempt, wait, max/2, max)
+		}
+	}
+	if wait := transport.backoff(20, nil); wait < 15*time.Second || wait >= 30*time.Second {
+		t.Errorf("attempt 20 waits %s, expected between 15s and the 30s cap", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	} {
+		wait, ok := parseRetryAfter(tc.value)
+		if wait != tc.wait || ok != tc.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, expected %s, %v", tc.value, wait, ok, tc.wait, tc.ok)
+		}
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 55*time.Second || wait > time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %s, %v, expected about a minute", date, wait, ok)
+	}
+}
+
+func TestShouldRetryRequest(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+	answered := func(status int) *http.Response {
+		return &http.Response{StatusCode: status, Header: http.Header{}}
+	}
+
+	for _, tc := range []struct {
+		method string
+		resp   *http.Response
+		err    error
+		retry  bool
+	}{
+		{http.MethodGet, nil, readErr, true},
+		{http.MethodPost, nil, dialErr, true},
+		{http.MethodPost, nil, readErr, false},
+		{http.MethodPost, answered(http.StatusTooManyRequests), nil, true},
+		{http.MethodPost, answered(http.StatusServiceUnavailable), nil, false},
+		{http.MethodPut, answered(http.StatusServiceUnavailable), nil, true},
+		{http.MethodGet, answered(http.StatusInternalServerError), nil, false},
+		{http.MethodGet, answered(http.StatusOK), nil, false},
+	} {
+		req, _ := http.NewRequest(tc.method, "https://awx.invalid/api/v2/ping/", nil)
+		outcome := fmt.Sprint(tc.err)
+		if tc.resp != nil {
+			outcome = http.StatusText(tc.resp.StatusCode)
+		}
+		if retry := shouldRetryRequest(req, tc.resp, tc.err); retry != tc.retry {
+			t.Errorf("%s failing with %s retried: %v, expected %v", tc.method, outcome, retry, tc.retry)
+		}
+	}
+
+	// a body without GetBody can not be sent again
+	req, _ := http.NewRequest(http.MethodPost, "https://awx.invalid/api/v2/ping/", io.NopCloser(strings.NewReader("{}")))
+	if shouldRetryRequest(req, answered(http.StatusTooManyRequests), nil) {
+		t.Error("POST with a body that can not be replayed retried")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "https://awx.invalid/api/v2/ping/", nil)
+	if shouldRetryRequest(req, nil, context.Canceled) {
+		t.Error("cancelled GET retried")
+	}
+}
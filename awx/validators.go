@@ -0,0 +1,77 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Choices AWX accepts for the enumerated attributes, checked at plan time so
+// a typo does not fail the apply half way.
+var (
+	jobTypes            = []string{"run", "check"}
+	scmTypes            = []string{"", "git", "hg", "svn", "insights", "archive"}
+	credentialTypeKinds = []string{"cloud", "net"}
+	variablesModes      = []string{variablesModeReplace, variablesModeMerge}
+	webhookServices     = []string{"", "github", "gitlab", "bitbucket_dc"}
+	notificationTypes   = []string{"awssns", "email", "grafana", "irc", "mattermost", "pagerduty", "rocketchat", "slack", "twilio", "webhook"}
+	// inventorySources lists the sources of every supported version, tower,
+	// cloudforms and custom were replaced by controller and scm in later ones.
+	inventorySources = []string{
+		"file", "constructed", "scm", "ec2", "gce", "azure_rm", "vmware", "satellite6", "openstack",
+		"rhv", "controller", "insights", "terraform", "openshift_virtualization", "tower", "cloudforms", "custom",
+	}
+)
+
+var (
+	validateJobType            = validation.ToDiagFunc(validation.StringInSlice(jobTypes, false))
+	validateSCMType            = validation.ToDiagFunc(validation.StringInSlice(scmTypes, false))
+	validateCredentialTypeKind = validation.ToDiagFunc(validation.StringInSlice(credentialTypeKinds, false))
+	validateNotificationType   = validation.ToDiagFunc(validation.StringInSlice(notificationTypes, false))
+	validateVariablesMode      = validation.ToDiagFunc(validation.StringInSlice(variablesModes, false))
+	validateInventorySource    = validation.ToDiagFunc(validation.StringInSlice(inventorySources, false))
+	// job verbosity goes from 0 (normal) to 5 (WinRM debug)
+	validateJobVerbosity = validation.ToDiagFunc(validation.IntBetween(0, 5))
+	// inventory update verbosity goes from 0 (warning) to 2 (debug)
+	validateInventorySourceVerbosity = validation.ToDiagFunc(validation.IntBetween(0, 2))
+)
+
+// validateJSONObject checks a string attribute holds a JSON object, as the
+// inputs of credentials. An empty string is accepted.
+func validateJSONObject(i interface{}, path cty.Path) diag.Diagnostics {
+	s, ok := i.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid JSON",
+			Detail:        fmt.Sprintf("Expected a JSON object, got: %s", err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// validateJSONOrYAMLObject checks a string attribute holds a JSON or YAML
+// object, as the extra variables of jobs. An empty string is accepted.
+func validateJSONOrYAMLObject(i interface{}, path cty.Path) diag.Diagnostics {
+	s, ok := i.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	if _, err := decodeVariables(s); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid JSON or YAML",
+			Detail:        fmt.Sprintf("Expected a JSON or YAML object, got: %s", err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
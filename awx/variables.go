@@ -0,0 +1,297 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// Variables, as the variables of inventories or the extra_vars of job
+// templates, are configured as JSON or YAML. AWX gives back the text it was
+// sent for some of them and an object for others, so they are compared on
+// the variables they decode to rather than on their formatting.
+
+// decodeVariables decodes JSON or YAML variables. An empty string holds no
+// variables.
+func decodeVariables(s string) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	if s == "" {
+		return variables, nil
+	}
+	// YAML being a superset of JSON, JSON is only tried first for the JSON
+	// YAML rejects, as tabs
+	if json.Unmarshal([]byte(s), &variables) == nil {
+		return variables, nil
+	}
+	var decoded map[interface{}]interface{}
+	if err := yaml.Unmarshal([]byte(s), &decoded); err != nil {
+		return nil, err
+	}
+	return stringKeys(decoded).(map[string]interface{}), nil
+}
+
+// stringKeys converts the maps decoded from YAML to maps with string keys,
+// the only ones JSON encodes.
+func stringKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			converted[fmt.Sprint(key)] = stringKeys(value)
+		}
+		return converted
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = stringKeys(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = stringKeys(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// variablesEqual reports whether a and b hold the same variables, whatever
+// their format. Variables failing to decode are only equal to the same text.
+func variablesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	va, err := decodeVariables(a)
+	if err != nil {
+		return false
+	}
+	vb, err := decodeVariables(b)
+	if err != nil {
+		return false
+	}
+	// both are encoded to JSON, the numbers JSON and YAML decode to
+	// different types then compare equal
+	ja, errA := json.Marshal(va)
+	jb, errB := json.Marshal(vb)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(va, vb)
+	}
+	return string(ja) == string(jb)
+}
+
+// Variables can also be configured as a map, for plans to show the change of
+// each variable. Map values being strings, the ones holding JSON, as numbers,
+// booleans or the result of jsonencode, are sent decoded, the others as
+// strings.
+
+// variablesFromMap decodes the variables of a map attribute.
+func variablesFromMap(m map[string]string) map[string]interface{} {
+	variables := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		variables[key] = decodeVariableValue(value)
+	}
+	return variables
+}
+
+func decodeVariableValue(s string) interface{} {
+	var v interface{}
+	if json.Unmarshal([]byte(s), &v) != nil {
+		return s
+	}
+	return v
+}
+
+// variablesToMap encodes variables for a map attribute. The prior values
+// decoding to the same variable are kept, so that `[1, 2]` is not changed to
+// `[1,2]`.
+func variablesToMap(variables map[string]interface{}, prior map[string]string) map[string]string {
+	m := make(map[string]string, len(variables))
+	for key, value := range variables {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			log.Printf("[WARN] unable to encode the variable %s: %s", key, err)
+			continue
+		}
+		if priorValue, ok := prior[key]; ok {
+			if priorEncoded, err := json.Marshal(decodeVariableValue(priorValue)); err == nil && string(priorEncoded) == string(encoded) {
+				m[key] = priorValue
+				continue
+			}
+		}
+		// strings are kept as they are unless they would be decoded to
+		// something else, as "1"
+		if s, ok := value.(string); ok {
+			if _, isString := decodeVariableValue(s).(string); isString {
+				m[key] = s
+				continue
+			}
+		}
+		m[key] = string(encoded)
+	}
+	return m
+}
+
+// getVariablesText returns the variables of attribute, or of mapAttribute
+// encoded to JSON when the map is configured.
+func getVariablesText(d *schema.ResourceData, attribute, mapAttribute string) string {
+	m, _ := d.Get(mapAttribute).(map[string]interface{})
+	return variablesOrMapText(d.Get(attribute).(string), m)
+}
+
+// variablesOrMapText is the variables of text, or of m encoded to JSON when
+// it holds any.
+func variablesOrMapText(text string, m map[string]interface{}) string {
+	if len(m) == 0 {
+		return text
+	}
+	values := make(map[string]string, len(m))
+	for key, value := range m {
+		values[key] = value.(string)
+	}
+	encoded, _ := json.Marshal(variablesFromMap(values))
+	return string(encoded)
+}
+
+// setVariablesOrMap sets the variables read from AWX to mapAttribute when the
+// state holds a map, to attribute otherwise.
+func setVariablesOrMap(d *schema.ResourceData, attribute, mapAttribute, value string) {
+	m, _ := d.Get(mapAttribute).(map[string]interface{})
+	if len(m) == 0 {
+		setVariables(d, attribute, value)
+		return
+	}
+	variables, err := decodeVariables(value)
+	if err != nil {
+		log.Printf("[WARN] unable to decode %s: %s", attribute, err)
+		d.Set(mapAttribute, nil)
+		setVariables(d, attribute, value)
+		return
+	}
+	prior := make(map[string]string, len(m))
+	for key, value := range m {
+		prior[key] = value.(string)
+	}
+	d.Set(mapAttribute, variablesToMap(variables, prior))
+}
+
+// getVariables decodes the variables of attribute, for the endpoints taking
+// them as an object, as the extra_data of schedules.
+func getVariables(d *schema.ResourceData, attribute string) (map[string]interface{}, diag.Diagnostics) {
+	variables, err := decodeVariables(d.Get(attribute).(string))
+	if err != nil {
+		return nil, validateJSONOrYAMLObject(d.Get(attribute), cty.GetAttrPath(attribute))
+	}
+	return variables, nil
+}
+
+// suppressVariablesDiff is the DiffSuppressFunc of the variables attributes.
+func suppressVariablesDiff(k, old, new string, d *schema.ResourceData) bool {
+	return variablesEqual(old, new)
+}
+
+// setVariables sets the variables read from AWX, keeping the value of the
+// state when it holds the same variables.
+func setVariables(d *schema.ResourceData, attribute, value string) {
+	if current, _ := d.Get(attribute).(string); variablesEqual(current, value) {
+		return
+	}
+	d.Set(attribute, value)
+}
+
+// Variables modes: replace owns all the variables of an object, merge only
+// the keys Terraform declares, leaving alone the ones inventory syncs or
+// playbooks write.
+const (
+	variablesModeReplace = "replace"
+	variablesModeMerge   = "merge"
+)
+
+// variablesParam returns the variables to send for the object id of
+// collection, 0 when it is created. In merge mode the declared variables are
+// merged into the ones of the object, and the keys Terraform declared before
+// but no longer does are removed.
+func variablesParam(d *schema.ResourceData, client *awxClient, collection string, id int) (string, diag.Diagnostics) {
+	text := getVariablesText(d, "variables", "variables_map")
+	if d.Get("variables_mode").(string) != variablesModeMerge || id == 0 {
+		return text, nil
+	}
+	declared, err := decodeVariables(text)
+	if err != nil {
+		return "", validateJSONOrYAMLObject(d.Get("variables"), cty.GetAttrPath("variables"))
+	}
+	oldText, _ := d.GetChange("variables")
+	oldMap, _ := d.GetChange("variables_map")
+	previous, _ := decodeVariables(variablesOrMapText(oldText.(string), oldMap.(map[string]interface{})))
+
+	var object struct {
+		Variables string `json:"variables"`
+	}
+	if err := client.getJSON(fmt.Sprintf("/api/v2/%s/%d/", collection, id), &object, nil); err != nil {
+		return "", buildDiagNotFoundFail(collection, id, err)
+	}
+	current, err := decodeVariables(object.Variables)
+	if err != nil {
+		return "", diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to merge the variables",
+			Detail:        fmt.Sprintf("The variables of %s %d are not a JSON or YAML object: %s", collection, id, err),
+			AttributePath: cty.GetAttrPath("variables"),
+		}}
+	}
+	for key := range previous {
+		if _, ok := declared[key]; !ok {
+			delete(current, key)
+		}
+	}
+	for key, value := range declared {
+		current[key] = value
+	}
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	return string(encoded), nil
+}
+
+// ownedVariables returns the variables of value Terraform owns, all of them
+// or in merge mode the keys the state declares, so that the others do not
+// show as a drift.
+func ownedVariables(d *schema.ResourceData, value string) string {
+	mode, _ := d.Get("variables_mode").(string)
+	if mode == "" {
+		// an imported object owns all its variables
+		d.Set("variables_mode", variablesModeReplace)
+	}
+	if mode != variablesModeMerge {
+		return value
+	}
+	current, err := decodeVariables(value)
+	if err != nil {
+		return value
+	}
+	m, _ := d.Get("variables_map").(map[string]interface{})
+	declared, err := decodeVariables(variablesOrMapText(d.Get("variables").(string), m))
+	if err != nil {
+		return value
+	}
+	owned := make(map[string]interface{})
+	for key := range declared {
+		if v, ok := current[key]; ok {
+			owned[key] = v
+		}
+	}
+	if len(owned) == 0 {
+		return ""
+	}
+	encoded, err := json.Marshal(owned)
+	if err != nil {
+		return value
+	}
+	return string(encoded)
+}
@@ -0,0 +1,89 @@
+package awx
+
+import (
+	"testing"
+)
+
+func TestVariablesEqual(t *testing.T) {
+	for _, tc := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{"", "", true},
+		{"", "{}", true},
+		{"", "---", true},
+		{`{"foo": "bar"}`, "foo: bar", true},
+		{`{"foo":"bar","count":1}`, "count: 1\nfoo: bar\n", true},
+		{`{"nested": {"list": [1, "two"]}}`, "nested:\n  list:\n    - 1\n    - two\n", true},
+		{"foo: bar", "foo: baz", false},
+		{"foo: 1", `{"foo": "1"}`, false},
+		{"foo: bar", "", false},
+		{"foo: [", "foo: [", true},
+		{"foo: [", "foo: bar", false},
+	} {
+		if equal := variablesEqual(tc.a, tc.b); equal != tc.equal {
+			t.Errorf("variablesEqual(%q, %q) = %v, expected %v", tc.a, tc.b, equal, tc.equal)
+		}
+	}
+}
+
+func TestDecodeVariables(t *testing.T) {
+	variables, err := decodeVariables("foo: bar\nnested:\n  1: one\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested, ok := variables["nested"].(map[string]interface{})
+	if !ok || nested["1"] != "one" {
+		t.Errorf("nested variables not decoded with string keys: %#v", variables)
+	}
+
+	// JSON YAML rejects, as tabs, is still decoded
+	if _, err := decodeVariables("{\n\t\"foo\": \"bar\"\n}"); err != nil {
+		t.Errorf("JSON indented with tabs rejected: %s", err)
+	}
+	if _, err := decodeVariables("- foo"); err == nil {
+		t.Error("a list is not variables")
+	}
+}
+
+func TestMarshalYaml(t *testing.T) {
+	if encoded := marshalYaml(map[string]interface{}{"foo": "bar"}); encoded != "foo: bar\n" {
+		t.Errorf("marshalYaml = %q", encoded)
+	}
+	if encoded := marshalYaml(nil); encoded != "" {
+		t.Errorf("marshalYaml(nil) = %q", encoded)
+	}
+}
+
+func TestVariablesMap(t *testing.T) {
+	variables := variablesFromMap(map[string]string{
+		"name":  "web",
+		"port":  "80",
+		"zip":   `"01234"`,
+		"ports": "[80, 443]",
+	})
+	if variables["name"] != "web" || variables["port"] != float64(80) || variables["zip"] != "01234" {
+		t.Errorf("variablesFromMap = %#v", variables)
+	}
+
+	m := variablesToMap(map[string]interface{}{
+		"name":  "web",
+		"port":  80,
+		"zip":   "01234",
+		"code":  "1234",
+		"ports": []interface{}{80, 443},
+		"new":   map[string]interface{}{"a": "b"},
+	}, map[string]string{"ports": "[80, 443]", "port": "81"})
+	for key, expected := range map[string]string{
+		"name":  "web",
+		"port":  "80",
+		"zip":   "01234",
+		"code":  `"1234"`,
+		"ports": "[80, 443]",
+		"new":   `{"a":"b"}`,
+	} {
+		if m[key] != expected {
+			t.Errorf("variablesToMap()[%q] = %q, expected %q", key, m[key], expected)
+		}
+	}
+}